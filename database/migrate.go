@@ -1,13 +1,117 @@
 package database
 
 import (
+	"log"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"payroll/domain/model"
 )
 
+// moneyColumns lists the columns that stored amounts as double precision
+// before amounts became whole rupiah (model.Money).
+var moneyColumns = map[string][]string{
+	"users":          {"salary"},
+	"reimbursements": {"amount"},
+	"payslips":       {"base_salary", "overtime_pay", "reimbursement_total", "total_earnings", "total_deductions", "total_pay"},
+	"payslip_items":  {"rate", "amount"},
+}
+
 func Migrate(db *gorm.DB) {
-	err := db.AutoMigrate(&model.User{}, &model.Attendance{}, &model.Overtime{}, &model.Reimbursement{}, &model.PayrollPeriod{}, &model.Payslip{}, &model.AuditLog{})
+	if err := convertMoneyColumns(db); err != nil {
+		log.Println("Failed to convert money columns:", err)
+		return
+	}
+
+	err := db.AutoMigrate(&model.Company{}, &model.PayGroup{}, &model.User{}, &model.Attendance{}, &model.Overtime{}, &model.Reimbursement{}, &model.PayrollPeriod{}, &model.Payslip{}, &model.PayslipItem{}, &model.PayslipRecord{}, &model.PayslipSalarySegment{}, &model.AuditLog{}, &model.Holiday{}, &model.TaxPTKP{}, &model.TaxTERRate{}, &model.TaxBracket{}, &model.Deduction{}, &model.DeductionTransaction{}, &model.Allowance{}, &model.AllowanceAssignment{}, &model.Compensation{}, &model.Shift{}, &model.WorkSchedule{}, &model.WorkScheduleDay{}, &model.ScheduleAssignment{}, &model.LeaveType{}, &model.LeaveRequest{}, &model.LeaveBalance{})
 	if err != nil {
 		return
 	}
+
+	if err := seedCompensationHistory(db); err != nil {
+		log.Println("Failed to seed compensation history:", err)
+	}
+	if err := seedLeaveTypes(db); err != nil {
+		log.Println("Failed to seed leave types:", err)
+	}
+	if err := backfillPeriodStatus(db); err != nil {
+		log.Println("Failed to backfill payroll period status:", err)
+	}
+	if err := backfillReimbursementDates(db); err != nil {
+		log.Println("Failed to backfill reimbursement dates:", err)
+	}
+}
+
+// backfillPeriodStatus gives periods processed or paid before periods had a
+// status the status their flags say they are in; the others stay open.
+func backfillPeriodStatus(db *gorm.DB) error {
+	return db.Exec(`UPDATE payroll_periods
+		SET status = CASE WHEN is_paid THEN ? ELSE ? END
+		WHERE is_processed AND status = ?`,
+		model.PeriodPaid, model.PeriodProcessed, model.PeriodOpen).Error
+}
+
+// backfillReimbursementDates dates reimbursements submitted before they had
+// a date on the day they were submitted, which is what payroll used to pay
+// them by.
+func backfillReimbursementDates(db *gorm.DB) error {
+	return db.Exec(`UPDATE reimbursements SET date = DATE(created_at) WHERE date IS NULL`).Error
+}
+
+// defaultLeaveTypes are created on first start so leave can be requested
+// before an administrator sets up the catalog.
+var defaultLeaveTypes = []model.LeaveType{
+	// 12 days a year after 12 months of service; up to 6 days carry over
+	// and expire at the end of March.
+	{Code: "ANNUAL", Name: "Annual leave", Paid: true, IsActive: true,
+		AccrualDaysPerYear: 12, ServiceMonthsRequired: 12, CarryOverCap: 6, CarryOverExpiryMonths: 3, PayOutOnTermination: true},
+	{Code: "SICK", Name: "Sick leave", Paid: true, IsActive: true},
+	{Code: "MATERNITY", Name: "Maternity leave", Paid: true, IsActive: true},
+	{Code: "UNPAID", Name: "Unpaid leave", Paid: false, IsActive: true},
+}
+
+func seedLeaveTypes(db *gorm.DB) error {
+	leaveTypes := make([]model.LeaveType, len(defaultLeaveTypes))
+	copy(leaveTypes, defaultLeaveTypes)
+	return db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "code"}}, DoNothing: true}).Create(&leaveTypes).Error
+}
+
+// seedCompensationHistory gives every employee without a salary history an
+// entry for their current salary, effective and dated from when they were
+// created, so payroll keeps paying the same amounts once it reads the
+// history and no payslip already issued is taken for retro pay.
+func seedCompensationHistory(db *gorm.DB) error {
+	return db.Exec(`INSERT INTO compensations (user_id, salary, effective_date, reason, created_at, updated_at)
+		SELECT u.id, u.salary, DATE(u.created_at), 'Starting salary', u.created_at, NOW()
+		FROM users u
+		WHERE u.role = ? AND u.deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM compensations c WHERE c.user_id = u.id AND c.deleted_at IS NULL)`,
+		model.RoleEmployee).Error
+}
+
+// convertMoneyColumns turns existing floating point amount columns into
+// bigint, rounding half away from zero (ROUND on numeric) so converted
+// values follow the same rule as model.Money.
+func convertMoneyColumns(db *gorm.DB) error {
+	for table, columns := range moneyColumns {
+		if !db.Migrator().HasTable(table) {
+			continue
+		}
+		for _, column := range columns {
+			var dataType string
+			if err := db.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = ? AND column_name = ?", table, column).
+				Scan(&dataType).Error; err != nil {
+				return err
+			}
+			if dataType != "double precision" && dataType != "real" && dataType != "numeric" {
+				continue
+			}
+
+			if err := db.Exec("ALTER TABLE ? ALTER COLUMN ? TYPE bigint USING ROUND(?::numeric)",
+				clause.Table{Name: table}, clause.Column{Name: column}, clause.Column{Name: column}).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
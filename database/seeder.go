@@ -1,22 +1,43 @@
 package database
 
 import (
+	_ "embed"
+	"encoding/json"
 	"fmt"
+	"log"
 	"math/rand"
 	"payroll/domain/model"
+	"payroll/repositories"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+// pph21Table holds the PPh 21 rates loaded on a fresh database. Newer years
+// are loaded through PUT /api/admin/tax-tables.
+//
+//go:embed data/pph21_2024.json
+var pph21Table []byte
+
 func Seed(db *gorm.DB) {
+	if err := SeedTaxTables(db); err != nil {
+		log.Println("Failed to seed tax tables:", err)
+	}
+
 	var count int64
 	db.Model(&model.User{}).Count(&count)
 	if count > 0 {
 		return
 	}
 
+	company := &model.Company{
+		Name:             "Default Company",
+		ProrationPolicy:  model.ProrationFixedDivisor,
+		ProrationDivisor: model.DefaultProrationDivisor,
+	}
+	db.Create(company)
+
 	password, _ := bcrypt.GenerateFromPassword([]byte("admin123"), bcrypt.DefaultCost)
 	db.Create(&model.User{
 		Username: "admin",
@@ -29,10 +50,27 @@ func Seed(db *gorm.DB) {
 	for i := 1; i <= 100; i++ {
 		pass, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
 		db.Create(&model.User{
-			Username: fmt.Sprintf("employee%d", i),
-			Password: string(pass),
-			Salary:   float64(3_000_000 + rand.Intn(2_000_000)),
-			Role:     model.RoleEmployee,
+			Username:  fmt.Sprintf("employee%d", i),
+			Password:  string(pass),
+			Salary:    model.Money(3_000_000 + rand.Intn(2_000_000)),
+			Role:      model.RoleEmployee,
+			CompanyID: &company.ID,
 		})
 	}
 }
+
+// SeedTaxTables loads the bundled PPh 21 tables unless that year is already present.
+func SeedTaxTables(db *gorm.DB) error {
+	var table model.TaxTable
+	if err := json.Unmarshal(pph21Table, &table); err != nil {
+		return err
+	}
+
+	var count int64
+	db.Model(&model.TaxPTKP{}).Where("effective_year = ?", table.EffectiveYear).Count(&count)
+	if count > 0 {
+		return nil
+	}
+
+	return repositories.NewTaxRepository(db).ReplaceTable(&table)
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"payroll/domain/dto"
+	"payroll/usecase"
+	"payroll/utils"
+)
+
+type AllowanceHandler struct {
+	allowanceUsecase *usecase.AllowanceUsecase
+}
+
+func NewAllowanceHandler(allowanceUsecase *usecase.AllowanceUsecase) *AllowanceHandler {
+	return &AllowanceHandler{
+		allowanceUsecase: allowanceUsecase,
+	}
+}
+
+func (h *AllowanceHandler) GetAllowances(c *gin.Context) {
+	allowances, err := h.allowanceUsecase.GetAllowances()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get allowances", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Allowances retrieved successfully", allowances)
+}
+
+func (h *AllowanceHandler) CreateAllowance(c *gin.Context) {
+	var req dto.AllowanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	allowance, err := h.allowanceUsecase.CreateAllowance(&req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create allowance", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Allowance created successfully", allowance)
+}
+
+func (h *AllowanceHandler) UpdateAllowance(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid allowance id", err)
+		return
+	}
+
+	var req dto.AllowanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	allowance, err := h.allowanceUsecase.UpdateAllowance(id, &req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update allowance", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Allowance updated successfully", allowance)
+}
+
+func (h *AllowanceHandler) AssignAllowance(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid allowance id", err)
+		return
+	}
+
+	var req dto.AllowanceAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	assignment, err := h.allowanceUsecase.AssignAllowance(id, &req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to assign allowance", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Allowance assigned successfully", assignment)
+}
+
+func (h *AllowanceHandler) RemoveAssignment(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid allowance assignment id", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	if err := h.allowanceUsecase.RemoveAssignment(id, userID, ipAddress, requestID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to remove allowance assignment", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Allowance assignment removed successfully", nil)
+}
@@ -66,3 +66,28 @@ func (h *AttendanceHandler) SubmitAttendance(c *gin.Context) {
 
 	utils.SuccessResponse(c, http.StatusCreated, "Attendance submitted successfully", nil)
 }
+
+func (h *AttendanceHandler) RecordAttendance(c *gin.Context) {
+	employeeID, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid employee id", err)
+		return
+	}
+
+	var req dto.AttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	if err := h.attendanceUsecase.RecordAttendance(employeeID, &req, userID, ipAddress, requestID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to record attendance", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Attendance recorded successfully", nil)
+}
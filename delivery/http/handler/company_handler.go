@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"payroll/domain/dto"
+	"payroll/usecase"
+	"payroll/utils"
+)
+
+type CompanyHandler struct {
+	companyUsecase *usecase.CompanyUsecase
+}
+
+func NewCompanyHandler(companyUsecase *usecase.CompanyUsecase) *CompanyHandler {
+	return &CompanyHandler{
+		companyUsecase: companyUsecase,
+	}
+}
+
+func (h *CompanyHandler) CreateCompany(c *gin.Context) {
+	var req dto.CompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	company, err := h.companyUsecase.CreateCompany(&req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create company", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Company created successfully", company)
+}
+
+func (h *CompanyHandler) UpdateCompany(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid company id", err)
+		return
+	}
+
+	var req dto.CompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	company, err := h.companyUsecase.UpdateCompany(id, &req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update company", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Company updated successfully", company)
+}
+
+func (h *CompanyHandler) GetCompanies(c *gin.Context) {
+	companies, err := h.companyUsecase.GetCompanies()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get companies", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Companies retrieved successfully", companies)
+}
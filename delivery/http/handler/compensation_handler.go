@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"payroll/domain/dto"
+	"payroll/usecase"
+	"payroll/utils"
+	"strconv"
+)
+
+type CompensationHandler struct {
+	compensationUsecase *usecase.CompensationUsecase
+}
+
+func NewCompensationHandler(compensationUsecase *usecase.CompensationUsecase) *CompensationHandler {
+	return &CompensationHandler{
+		compensationUsecase: compensationUsecase,
+	}
+}
+
+func (h *CompensationHandler) GetCompensationHistory(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Query("user_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user id", errors.New("user_id is required"))
+		return
+	}
+
+	history, err := h.compensationUsecase.GetCompensationHistory(uint(employeeID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get salary history", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Salary history retrieved successfully", history)
+}
+
+func (h *CompensationHandler) CreateCompensation(c *gin.Context) {
+	var req dto.CompensationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	compensation, err := h.compensationUsecase.CreateCompensation(&req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to record salary change", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Salary change recorded successfully", compensation)
+}
+
+func (h *CompensationHandler) DeleteCompensation(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid salary change id", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	if err := h.compensationUsecase.DeleteCompensation(id, userID, ipAddress, requestID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to cancel salary change", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Salary change cancelled successfully", nil)
+}
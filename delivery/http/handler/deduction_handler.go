@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"payroll/domain/dto"
+	"payroll/usecase"
+	"payroll/utils"
+	"strconv"
+)
+
+type DeductionHandler struct {
+	deductionUsecase *usecase.DeductionUsecase
+}
+
+func NewDeductionHandler(deductionUsecase *usecase.DeductionUsecase) *DeductionHandler {
+	return &DeductionHandler{
+		deductionUsecase: deductionUsecase,
+	}
+}
+
+func (h *DeductionHandler) GetDeductions(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Query("user_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user id", errors.New("user_id is required"))
+		return
+	}
+
+	deductions, err := h.deductionUsecase.GetDeductions(uint(employeeID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get deductions", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Deductions retrieved successfully", deductions)
+}
+
+func (h *DeductionHandler) CreateDeduction(c *gin.Context) {
+	var req dto.DeductionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	deduction, err := h.deductionUsecase.CreateDeduction(&req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create deduction", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Deduction created successfully", deduction)
+}
+
+func (h *DeductionHandler) CancelDeduction(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid deduction id", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	deduction, err := h.deductionUsecase.CancelDeduction(id, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to cancel deduction", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Deduction cancelled successfully", deduction)
+}
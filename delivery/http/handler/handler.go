@@ -0,0 +1,15 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+)
+
+// paramID reads a numeric path parameter such as /companies/:id.
+func paramID(c *gin.Context, name string) (uint, error) {
+	var id uint
+	if n, err := fmt.Sscanf(c.Param(name), "%d", &id); err != nil || n != 1 {
+		return 0, fmt.Errorf("invalid %s", name)
+	}
+	return id, nil
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"payroll/domain/dto"
+	"payroll/usecase"
+	"payroll/utils"
+	"time"
+)
+
+type HolidayHandler struct {
+	holidayUsecase *usecase.HolidayUsecase
+}
+
+func NewHolidayHandler(holidayUsecase *usecase.HolidayUsecase) *HolidayHandler {
+	return &HolidayHandler{
+		holidayUsecase: holidayUsecase,
+	}
+}
+
+func (h *HolidayHandler) GetHolidays(c *gin.Context) {
+	year := time.Now().Year()
+	if y := c.Query("year"); y != "" {
+		if n, err := fmt.Sscanf(y, "%d", &year); err != nil || n != 1 {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid year", err)
+			return
+		}
+	}
+
+	holidays, err := h.holidayUsecase.GetHolidays(year)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get holidays", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Holidays retrieved successfully", holidays)
+}
+
+func (h *HolidayHandler) CreateHoliday(c *gin.Context) {
+	var req dto.HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	holiday, err := h.holidayUsecase.CreateHoliday(&req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create holiday", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Holiday created successfully", holiday)
+}
+
+func (h *HolidayHandler) UpdateHoliday(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid holiday id", err)
+		return
+	}
+
+	var req dto.HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	holiday, err := h.holidayUsecase.UpdateHoliday(id, &req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update holiday", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Holiday updated successfully", holiday)
+}
+
+func (h *HolidayHandler) DeleteHoliday(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid holiday id", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	if err := h.holidayUsecase.DeleteHoliday(id, userID, ipAddress, requestID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to delete holiday", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Holiday deleted successfully", nil)
+}
+
+func (h *HolidayHandler) ImportHolidays(c *gin.Context) {
+	var req dto.HolidayImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	holidays, err := h.holidayUsecase.ImportHolidays(&req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to import holidays", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Holidays imported successfully", holidays)
+}
@@ -0,0 +1,272 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"payroll/domain/dto"
+	"payroll/usecase"
+	"payroll/utils"
+	"strconv"
+	"time"
+)
+
+type LeaveHandler struct {
+	leaveUsecase *usecase.LeaveUsecase
+}
+
+func NewLeaveHandler(leaveUsecase *usecase.LeaveUsecase) *LeaveHandler {
+	return &LeaveHandler{
+		leaveUsecase: leaveUsecase,
+	}
+}
+
+func (h *LeaveHandler) GetLeaveTypes(c *gin.Context) {
+	leaveTypes, err := h.leaveUsecase.GetLeaveTypes()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get leave types", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Leave types retrieved successfully", leaveTypes)
+}
+
+func (h *LeaveHandler) CreateLeaveType(c *gin.Context) {
+	var req dto.LeaveTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	leaveType, err := h.leaveUsecase.CreateLeaveType(&req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create leave type", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Leave type created successfully", leaveType)
+}
+
+func (h *LeaveHandler) UpdateLeaveType(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid leave type id", err)
+		return
+	}
+
+	var req dto.LeaveTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	leaveType, err := h.leaveUsecase.UpdateLeaveType(id, &req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update leave type", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Leave type updated successfully", leaveType)
+}
+
+// GetLeaveRequests lists leave requests for admins, optionally filtered by
+// user_id and status.
+func (h *LeaveHandler) GetLeaveRequests(c *gin.Context) {
+	var employeeID uint64
+	if value := c.Query("user_id"); value != "" {
+		var err error
+		employeeID, err = strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user id", errors.New("user_id must be a number"))
+			return
+		}
+	}
+
+	requests, err := h.leaveUsecase.GetLeaveRequests(uint(employeeID), c.Query("status"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get leave requests", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Leave requests retrieved successfully", requests)
+}
+
+func (h *LeaveHandler) ApproveLeave(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid leave request id", err)
+		return
+	}
+
+	var req dto.LeaveReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	request, err := h.leaveUsecase.ApproveLeave(id, &req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to approve leave request", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Leave request approved successfully", request)
+}
+
+func (h *LeaveHandler) RejectLeave(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid leave request id", err)
+		return
+	}
+
+	var req dto.LeaveReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	request, err := h.leaveUsecase.RejectLeave(id, &req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to reject leave request", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Leave request rejected successfully", request)
+}
+
+func (h *LeaveHandler) SubmitLeave(c *gin.Context) {
+	var req dto.LeaveRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	request, err := h.leaveUsecase.SubmitLeave(userID, &req, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to submit leave request", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Leave request submitted successfully", request)
+}
+
+// GetMyLeaveRequests lists the leave requests of the signed-in employee.
+func (h *LeaveHandler) GetMyLeaveRequests(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	requests, err := h.leaveUsecase.GetLeaveRequests(userID, c.Query("status"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get leave requests", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Leave requests retrieved successfully", requests)
+}
+
+func (h *LeaveHandler) CancelLeave(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid leave request id", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	request, err := h.leaveUsecase.CancelLeave(id, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to cancel leave request", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Leave request cancelled successfully", request)
+}
+
+// GetLeaveBalances returns an employee's leave balances for admins.
+func (h *LeaveHandler) GetLeaveBalances(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Query("user_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user id", errors.New("user_id is required"))
+		return
+	}
+	year := time.Now().Year()
+	if y := c.Query("year"); y != "" {
+		if n, err := fmt.Sscanf(y, "%d", &year); err != nil || n != 1 {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid year", err)
+			return
+		}
+	}
+
+	balances, err := h.leaveUsecase.GetLeaveBalances(uint(employeeID), year)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to get leave balances", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Leave balances retrieved successfully", balances)
+}
+
+// GetMyLeaveBalances returns the leave balances of the signed-in employee.
+func (h *LeaveHandler) GetMyLeaveBalances(c *gin.Context) {
+	year := time.Now().Year()
+	if y := c.Query("year"); y != "" {
+		if n, err := fmt.Sscanf(y, "%d", &year); err != nil || n != 1 {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid year", err)
+			return
+		}
+	}
+
+	userID := c.GetUint("user_id")
+
+	balances, err := h.leaveUsecase.GetLeaveBalances(userID, year)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to get leave balances", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Leave balances retrieved successfully", balances)
+}
+
+func (h *LeaveHandler) AccrueLeave(c *gin.Context) {
+	var req dto.LeaveAccrualRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	updated, err := h.leaveUsecase.AccrueLeave(&req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to accrue leave", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Leave accrued successfully", gin.H{"balances": updated})
+}
@@ -36,3 +36,28 @@ func (h *OvertimeHandler) SubmitOvertime(c *gin.Context) {
 
 	utils.SuccessResponse(c, http.StatusCreated, "Overtime submitted successfully", nil)
 }
+
+func (h *OvertimeHandler) RecordOvertime(c *gin.Context) {
+	employeeID, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid employee id", err)
+		return
+	}
+
+	var req dto.OvertimeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	if err := h.overtimeUsecase.RecordOvertime(employeeID, &req, userID, ipAddress, requestID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to record overtime", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Overtime recorded successfully", nil)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"payroll/domain/dto"
+	"payroll/usecase"
+	"payroll/utils"
+)
+
+type PayGroupHandler struct {
+	payGroupUsecase *usecase.PayGroupUsecase
+}
+
+func NewPayGroupHandler(payGroupUsecase *usecase.PayGroupUsecase) *PayGroupHandler {
+	return &PayGroupHandler{
+		payGroupUsecase: payGroupUsecase,
+	}
+}
+
+func (h *PayGroupHandler) GetPayGroups(c *gin.Context) {
+	groups, err := h.payGroupUsecase.GetPayGroups()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get pay groups", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Pay groups retrieved successfully", groups)
+}
+
+func (h *PayGroupHandler) CreatePayGroup(c *gin.Context) {
+	var req dto.PayGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	group, err := h.payGroupUsecase.CreatePayGroup(&req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create pay group", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Pay group created successfully", group)
+}
+
+func (h *PayGroupHandler) UpdatePayGroup(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid pay group id", err)
+		return
+	}
+
+	var req dto.PayGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	group, err := h.payGroupUsecase.UpdatePayGroup(id, &req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update pay group", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Pay group updated successfully", group)
+}
+
+// GeneratePeriods creates the pay group's payroll periods for a date range.
+func (h *PayGroupHandler) GeneratePeriods(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid pay group id", err)
+		return
+	}
+
+	var req dto.PeriodGenerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	periods, err := h.payGroupUsecase.GeneratePeriods(id, &req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to generate payroll periods", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Payroll periods generated successfully", periods)
+}
@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"payroll/domain/dto"
 	"payroll/usecase"
 	"payroll/utils"
+	"strconv"
 )
 
 type PayrollHandler struct {
@@ -39,6 +41,54 @@ func (h *PayrollHandler) CreatePayrollPeriod(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusCreated, "Payroll period created successfully", period)
 }
 
+// GetPayrollPeriods lists payroll periods, optionally filtered by
+// pay_group_id and status.
+func (h *PayrollHandler) GetPayrollPeriods(c *gin.Context) {
+	var payGroupID uint64
+	if value := c.Query("pay_group_id"); value != "" {
+		var err error
+		payGroupID, err = strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid pay group id", errors.New("pay_group_id must be a number"))
+			return
+		}
+	}
+
+	periods, err := h.payrollUsecase.GetPayrollPeriods(uint(payGroupID), c.Query("status"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to get payroll periods", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Payroll periods retrieved successfully", periods)
+}
+
+func (h *PayrollHandler) TransitionPayrollPeriod(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid payroll period id", err)
+		return
+	}
+
+	var req dto.PayrollPeriodTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	period, err := h.payrollUsecase.TransitionPayrollPeriod(id, &req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update payroll period status", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Payroll period status updated successfully", period)
+}
+
 func (h *PayrollHandler) RunPayroll(c *gin.Context) {
 	var req dto.PayrollRunRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -50,12 +100,93 @@ func (h *PayrollHandler) RunPayroll(c *gin.Context) {
 	ipAddress := c.ClientIP()
 	requestID := c.GetString("request_id")
 
-	if err := h.payrollUsecase.RunPayroll(&req, userID, ipAddress, requestID); err != nil {
+	report, err := h.payrollUsecase.RunPayroll(&req, userID, ipAddress, requestID)
+	if err != nil {
+		var runErr *usecase.PayrollRunError
+		if errors.As(err, &runErr) {
+			utils.ErrorResponseWithData(c, http.StatusUnprocessableEntity, "Failed to run payroll", err, runErr.Report)
+			return
+		}
 		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to run payroll", err)
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Payroll processed successfully", nil)
+	utils.SuccessResponse(c, http.StatusOK, "Payroll processed successfully", report)
+}
+
+func (h *PayrollHandler) ReversePayroll(c *gin.Context) {
+	var req dto.PayrollRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	if err := h.payrollUsecase.ReversePayroll(&req, userID, ipAddress, requestID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to reverse payroll", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Payroll reversed successfully", nil)
+}
+
+func (h *PayrollHandler) MarkPayrollPaid(c *gin.Context) {
+	var req dto.PayrollRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	period, err := h.payrollUsecase.MarkPayrollPaid(&req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to mark payroll paid", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Payroll marked paid successfully", period)
+}
+
+func (h *PayrollHandler) CorrectPayroll(c *gin.Context) {
+	var req dto.PayrollCorrectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	adjustments, err := h.payrollUsecase.CorrectPayroll(&req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to run payroll correction", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Payroll correction processed successfully", adjustments)
+}
+
+func (h *PayrollHandler) PreviewPayroll(c *gin.Context) {
+	var req dto.PayrollRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	preview, err := h.payrollUsecase.PreviewPayroll(&req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to preview payroll", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Payroll preview calculated successfully", preview)
 }
 
 func (h *PayrollHandler) GeneratePayslip(c *gin.Context) {
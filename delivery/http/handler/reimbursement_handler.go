@@ -36,3 +36,28 @@ func (h *ReimbursementHandler) SubmitReimbursement(c *gin.Context) {
 
 	utils.SuccessResponse(c, http.StatusCreated, "Reimbursement submitted successfully", nil)
 }
+
+func (h *ReimbursementHandler) RecordReimbursement(c *gin.Context) {
+	employeeID, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid employee id", err)
+		return
+	}
+
+	var req dto.ReimbursementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	if err := h.reimbursementUsecase.RecordReimbursement(employeeID, &req, userID, ipAddress, requestID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to record reimbursement", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Reimbursement recorded successfully", nil)
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"payroll/domain/dto"
+	"payroll/usecase"
+	"payroll/utils"
+	"strconv"
+)
+
+type ScheduleHandler struct {
+	scheduleUsecase *usecase.ScheduleUsecase
+}
+
+func NewScheduleHandler(scheduleUsecase *usecase.ScheduleUsecase) *ScheduleHandler {
+	return &ScheduleHandler{
+		scheduleUsecase: scheduleUsecase,
+	}
+}
+
+func (h *ScheduleHandler) GetShifts(c *gin.Context) {
+	shifts, err := h.scheduleUsecase.GetShifts()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get shifts", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Shifts retrieved successfully", shifts)
+}
+
+func (h *ScheduleHandler) CreateShift(c *gin.Context) {
+	var req dto.ShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	shift, err := h.scheduleUsecase.CreateShift(&req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create shift", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Shift created successfully", shift)
+}
+
+func (h *ScheduleHandler) UpdateShift(c *gin.Context) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid shift id", err)
+		return
+	}
+
+	var req dto.ShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	shift, err := h.scheduleUsecase.UpdateShift(id, &req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update shift", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Shift updated successfully", shift)
+}
+
+func (h *ScheduleHandler) GetSchedules(c *gin.Context) {
+	schedules, err := h.scheduleUsecase.GetSchedules()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get work schedules", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Work schedules retrieved successfully", schedules)
+}
+
+func (h *ScheduleHandler) CreateSchedule(c *gin.Context) {
+	var req dto.WorkScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	schedule, err := h.scheduleUsecase.CreateSchedule(&req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create work schedule", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Work schedule created successfully", schedule)
+}
+
+func (h *ScheduleHandler) GetAssignments(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Query("user_id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user id", errors.New("user_id is required"))
+		return
+	}
+
+	assignments, err := h.scheduleUsecase.GetAssignments(uint(employeeID))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get schedule assignments", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Schedule assignments retrieved successfully", assignments)
+}
+
+func (h *ScheduleHandler) AssignSchedule(c *gin.Context) {
+	var req dto.ScheduleAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	assignment, err := h.scheduleUsecase.AssignSchedule(&req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to assign work schedule", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Work schedule assigned successfully", assignment)
+}
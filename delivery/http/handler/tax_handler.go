@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"payroll/domain/dto"
+	"payroll/usecase"
+	"payroll/utils"
+	"time"
+)
+
+type TaxHandler struct {
+	taxUsecase *usecase.TaxUsecase
+}
+
+func NewTaxHandler(taxUsecase *usecase.TaxUsecase) *TaxHandler {
+	return &TaxHandler{
+		taxUsecase: taxUsecase,
+	}
+}
+
+func (h *TaxHandler) GetTaxTable(c *gin.Context) {
+	year := time.Now().Year()
+	if y := c.Query("year"); y != "" {
+		if n, err := fmt.Sscanf(y, "%d", &year); err != nil || n != 1 {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid year", err)
+			return
+		}
+	}
+
+	table, err := h.taxUsecase.GetTaxTable(year)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Tax table not found", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Tax table retrieved successfully", table)
+}
+
+func (h *TaxHandler) ReplaceTaxTable(c *gin.Context) {
+	var req dto.TaxTableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	table, err := h.taxUsecase.ReplaceTaxTable(&req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update tax table", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Tax table updated successfully", table)
+}
@@ -48,3 +48,29 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 
 	utils.SuccessResponse(c, http.StatusOK, "Profile retrieved successfully", user)
 }
+
+func (h *UserHandler) UpdateEmployee(c *gin.Context) {
+	employeeID, err := paramID(c, "id")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid employee id", err)
+		return
+	}
+
+	var req dto.EmployeeUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	ipAddress := c.ClientIP()
+	requestID := c.GetString("request_id")
+
+	user, err := h.userUsecase.UpdateEmployee(employeeID, &req, userID, ipAddress, requestID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update employee", err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Employee updated successfully", user)
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "payroll/domain/model"
+
+type AllowanceRequest struct {
+	Code     string      `json:"code" binding:"required"`
+	Name     string      `json:"name" binding:"required"`
+	Basis    string      `json:"basis" binding:"required"`
+	Amount   model.Money `json:"amount" binding:"min=0"`
+	Taxable  *bool       `json:"taxable"`
+	IsActive *bool       `json:"is_active"`
+}
+
+// AllowanceAssignmentRequest grants an allowance to either one employee or a
+// grade. Amount overrides the catalog amount for this assignment.
+type AllowanceAssignmentRequest struct {
+	UserID *uint        `json:"user_id"`
+	Grade  string       `json:"grade"`
+	Amount *model.Money `json:"amount" binding:"omitempty,min=0"`
+}
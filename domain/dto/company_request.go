@@ -0,0 +1,15 @@
+package dto
+
+import "payroll/domain/model"
+
+type CompanyRequest struct {
+	Name             string      `json:"name" binding:"required"`
+	ProrationPolicy  string      `json:"proration_policy"`
+	ProrationDivisor int         `json:"proration_divisor" binding:"min=0,max=31"`
+	JKKRiskClass     string      `json:"jkk_risk_class"`
+	NetPayFloor      model.Money `json:"net_pay_floor" binding:"min=0"`
+	LatenessPolicy   string      `json:"lateness_policy"`
+	LatenessRate     model.Money `json:"lateness_rate" binding:"min=0"`
+
+	SubmissionFutureDays int `json:"submission_future_days" binding:"min=0,max=366"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "payroll/domain/model"
+
+// CompensationRequest records a salary change, effective from EffectiveDate.
+// Future dates schedule a raise ahead of time.
+type CompensationRequest struct {
+	UserID        uint        `json:"user_id" binding:"required"`
+	Salary        model.Money `json:"salary" binding:"required,min=1"`
+	EffectiveDate string      `json:"effective_date" binding:"required"`
+	Reason        string      `json:"reason"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "payroll/domain/model"
+
+// DeductionRequest creates a deduction for an employee. One-off and recurring
+// deductions take Amount; loans take Principal and InstallmentCount and
+// derive the installment. InstallmentCount limits a recurring deduction to
+// that many periods (0 = until cancelled).
+type DeductionRequest struct {
+	UserID           uint        `json:"user_id" binding:"required"`
+	Type             string      `json:"type" binding:"required"`
+	Description      string      `json:"description" binding:"required"`
+	Amount           model.Money `json:"amount" binding:"min=0"`
+	Principal        model.Money `json:"principal" binding:"min=0"`
+	InstallmentCount int         `json:"installment_count" binding:"min=0"`
+	StartDate        string      `json:"start_date" binding:"required"`
+}
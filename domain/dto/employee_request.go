@@ -0,0 +1,17 @@
+package dto
+
+// EmployeeUpdateRequest changes the payroll settings of an employee. Fields
+// left out of the request keep their current value.
+type EmployeeUpdateRequest struct {
+	CompanyID       *uint   `json:"company_id"`
+	PayGroupID      *uint   `json:"pay_group_id"` // zero takes the employee out of their pay group
+	Grade           *string `json:"grade"`
+	ProrationPolicy *string `json:"proration_policy"`
+	TaxMarried      *bool   `json:"tax_married"`
+	TaxDependents   *int    `json:"tax_dependents" binding:"omitempty,min=0"`
+	NPWP            *string `json:"npwp"`
+
+	EmploymentStatus *string `json:"employment_status"`
+	HireDate         *string `json:"hire_date"`
+	TerminationDate  *string `json:"termination_date"` // an empty string clears it
+}
@@ -0,0 +1,15 @@
+package dto
+
+type HolidayRequest struct {
+	Date string `json:"date" binding:"required"`
+	Name string `json:"name" binding:"required"`
+	Type string `json:"type"`
+}
+
+// HolidayImportRequest loads the holiday list of a whole year, typically the
+// government decree of public holidays and collective leave days. Entries
+// for dates that already exist replace them.
+type HolidayImportRequest struct {
+	Year     int              `json:"year" binding:"required,min=2000,max=2100"`
+	Holidays []HolidayRequest `json:"holidays" binding:"required,min=1,dive"`
+}
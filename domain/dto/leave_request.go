@@ -0,0 +1,34 @@
+package dto
+
+// LeaveTypeRequest defines a leave type. A positive AccrualDaysPerYear makes
+// requests of the type draw on a yearly balance.
+type LeaveTypeRequest struct {
+	Code     string `json:"code" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	Paid     *bool  `json:"paid"`
+	IsActive *bool  `json:"is_active"`
+
+	AccrualDaysPerYear    float64 `json:"accrual_days_per_year" binding:"min=0"`
+	ServiceMonthsRequired int     `json:"service_months_required" binding:"min=0"`
+	CarryOverCap          float64 `json:"carry_over_cap" binding:"min=0"`
+	CarryOverExpiryMonths int     `json:"carry_over_expiry_months" binding:"min=0,max=12"`
+	PayOutOnTermination   bool    `json:"pay_out_on_termination"`
+}
+
+// LeaveRequestRequest asks for leave from StartDate to EndDate inclusive.
+type LeaveRequestRequest struct {
+	LeaveTypeID uint   `json:"leave_type_id" binding:"required"`
+	StartDate   string `json:"start_date" binding:"required"`
+	EndDate     string `json:"end_date" binding:"required"`
+	Reason      string `json:"reason"`
+}
+
+type LeaveReviewRequest struct {
+	Note string `json:"note"`
+}
+
+// LeaveAccrualRequest brings leave balances up to date as of AsOf, today
+// when empty.
+type LeaveAccrualRequest struct {
+	AsOf string `json:"as_of"`
+}
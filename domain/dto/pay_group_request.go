@@ -0,0 +1,17 @@
+package dto
+
+// PayGroupRequest defines a pay group. AnchorDate is the first day of a
+// weekly or bi-weekly period; monthly groups may leave it out.
+type PayGroupRequest struct {
+	Name       string `json:"name" binding:"required"`
+	Frequency  string `json:"frequency" binding:"required"`
+	AnchorDate string `json:"anchor_date"`
+	IsActive   *bool  `json:"is_active"`
+}
+
+// PeriodGenerationRequest creates the pay group's periods starting from
+// From to To inclusive.
+type PeriodGenerationRequest struct {
+	From string `json:"from" binding:"required"`
+	To   string `json:"to" binding:"required"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// PayrollCorrectionRequest recalculates a processed period for the selected
+// employees and issues adjustment payslips for the differences.
+type PayrollCorrectionRequest struct {
+	PayrollPeriodID uint   `json:"payroll_period_id" binding:"required"`
+	UserIDs         []uint `json:"user_ids" binding:"required,min=1"`
+	Reason          string `json:"reason" binding:"required"`
+}
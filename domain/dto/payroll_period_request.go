@@ -4,3 +4,8 @@ type PayrollPeriodRequest struct {
 	StartDate string `json:"start_date" binding:"required"`
 	EndDate   string `json:"end_date" binding:"required"`
 }
+
+// PayrollPeriodTransitionRequest moves a payroll period to Status.
+type PayrollPeriodTransitionRequest struct {
+	Status string `json:"status" binding:"required"`
+}
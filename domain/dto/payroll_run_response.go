@@ -0,0 +1,20 @@
+package dto
+
+// PayrollRunFailure explains why one employee could not be paid.
+type PayrollRunFailure struct {
+	UserID   uint   `json:"user_id"`
+	Username string `json:"username"`
+	// Stage is "calculate" when the payslip could not be worked out and
+	// "store" when it could not be saved.
+	Stage string `json:"stage"`
+	Error string `json:"error"`
+}
+
+// PayrollRunReport is the outcome of a payroll run. A run with failures
+// stores nothing.
+type PayrollRunReport struct {
+	PayrollPeriodID uint                `json:"payroll_period_id"`
+	EmployeeCount   int                 `json:"employee_count"`
+	Processed       int                 `json:"processed"`
+	Failures        []PayrollRunFailure `json:"failures"`
+}
@@ -2,9 +2,27 @@ package dto
 
 import "payroll/domain/model"
 
+// PayrollCostLine totals one employer-paid item, such as the employer share
+// of BPJS JHT, across all payslips of a period.
+type PayrollCostLine struct {
+	Code   string      `json:"code"`
+	Label  string      `json:"label"`
+	Amount model.Money `json:"amount"`
+}
+
 type PayrollSummaryResponse struct {
 	PayrollPeriod model.PayrollPeriod      `json:"payroll_period"`
 	Payslips      []map[string]interface{} `json:"payslips"`
-	TotalPayout   float64                  `json:"total_payout"`
+	TotalPayout   model.Money              `json:"total_payout"`
 	EmployeeCount int                      `json:"employee_count"`
+	// Preview is set when the payslips were calculated but not stored.
+	Preview bool `json:"preview"`
+	// Failures lists the employees a preview could not calculate.
+	Failures []PayrollRunFailure `json:"failures,omitempty"`
+
+	// Employer cost is gross earnings plus employer contributions.
+	TotalGross                 model.Money       `json:"total_gross"`
+	TotalEmployerContributions model.Money       `json:"total_employer_contributions"`
+	TotalEmployerCost          model.Money       `json:"total_employer_cost"`
+	EmployerCostLines          []PayrollCostLine `json:"employer_cost_lines"`
 }
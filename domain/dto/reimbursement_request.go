@@ -1,6 +1,9 @@
 package dto
 
+import "payroll/domain/model"
+
 type ReimbursementRequest struct {
-	Amount      float64 `json:"amount" binding:"required,min=0"`
-	Description string  `json:"description" binding:"required"`
+	Amount      model.Money `json:"amount" binding:"required,min=0"`
+	Description string      `json:"description" binding:"required"`
+	Date        string      `json:"date"` // the day the expense was made; today when left out
 }
@@ -0,0 +1,34 @@
+package dto
+
+// ShiftRequest defines a shift. StartTime and EndTime are "15:04" clock
+// times; an EndTime before StartTime is an overnight shift.
+type ShiftRequest struct {
+	Code         string `json:"code" binding:"required"`
+	Name         string `json:"name" binding:"required"`
+	StartTime    string `json:"start_time" binding:"required"`
+	EndTime      string `json:"end_time" binding:"required"`
+	BreakMinutes int    `json:"break_minutes" binding:"min=0"`
+
+	LateGraceMinutes       int `json:"late_grace_minutes" binding:"min=0"`
+	EarlyLeaveGraceMinutes int `json:"early_leave_grace_minutes" binding:"min=0"`
+}
+
+// WorkScheduleRequest defines a repeating pattern of shifts. Cycle day 0
+// falls on AnchorDate; days left out of Days are rest days.
+type WorkScheduleRequest struct {
+	Name       string                   `json:"name" binding:"required"`
+	CycleDays  int                      `json:"cycle_days" binding:"required,min=1"`
+	AnchorDate string                   `json:"anchor_date" binding:"required"`
+	Days       []WorkScheduleDayRequest `json:"days" binding:"required,dive"`
+}
+
+type WorkScheduleDayRequest struct {
+	DayIndex int  `json:"day_index" binding:"min=0"`
+	ShiftID  uint `json:"shift_id" binding:"required"`
+}
+
+type ScheduleAssignmentRequest struct {
+	UserID         uint   `json:"user_id" binding:"required"`
+	WorkScheduleID uint   `json:"work_schedule_id" binding:"required"`
+	EffectiveDate  string `json:"effective_date" binding:"required"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "payroll/domain/model"
+
+type TaxPTKPRequest struct {
+	Status      string      `json:"status" binding:"required"`
+	Amount      model.Money `json:"amount" binding:"min=0"`
+	TERCategory string      `json:"ter_category" binding:"required"`
+}
+
+type TaxRateRequest struct {
+	Category        string      `json:"category"`
+	MaxIncome       model.Money `json:"max_income" binding:"min=0"`
+	RateBasisPoints int64       `json:"rate_basis_points" binding:"min=0,max=10000"`
+}
+
+// TaxTableRequest replaces the PPh 21 tables in force from EffectiveYear.
+// The last TER rate of each category and the last bracket must have a
+// max_income of 0 (no upper limit).
+type TaxTableRequest struct {
+	EffectiveYear int              `json:"effective_year" binding:"required,min=2000,max=2100"`
+	PTKP          []TaxPTKPRequest `json:"ptkp" binding:"required,min=1,dive"`
+	TERRates      []TaxRateRequest `json:"ter_rates" binding:"required,min=1,dive"`
+	Brackets      []TaxRateRequest `json:"brackets" binding:"required,min=1,dive"`
+}
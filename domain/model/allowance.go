@@ -0,0 +1,51 @@
+package model
+
+type AllowanceBasis string
+
+const (
+	// AllowanceFixed pays Amount once per period.
+	AllowanceFixed AllowanceBasis = "fixed"
+	// AllowancePerAttendanceDay pays Amount for every attended day.
+	AllowancePerAttendanceDay AllowanceBasis = "per_attendance_day"
+	// AllowancePerOvertimeDay pays Amount for every day with overtime.
+	AllowancePerOvertimeDay AllowanceBasis = "per_overtime_day"
+)
+
+func (b AllowanceBasis) IsValid() bool {
+	return b == AllowanceFixed || b == AllowancePerAttendanceDay || b == AllowancePerOvertimeDay
+}
+
+// Allowance is a catalog entry; employees receive it through assignments.
+type Allowance struct {
+	BaseModel
+	Code     string         `gorm:"uniqueIndex;not null" json:"code"`
+	Name     string         `gorm:"not null" json:"name"`
+	Basis    AllowanceBasis `gorm:"not null" json:"basis"`
+	Amount   Money          `gorm:"not null" json:"amount"`
+	Taxable  bool           `gorm:"not null" json:"taxable"`
+	IsActive bool           `gorm:"not null" json:"is_active"`
+
+	Assignments []AllowanceAssignment `json:"assignments,omitempty"`
+}
+
+// AllowanceAssignment grants an allowance to one employee (UserID) or to
+// every employee of a grade (Grade). An employee assignment takes precedence
+// over a grade assignment of the same allowance. Amount overrides the
+// catalog amount when set.
+type AllowanceAssignment struct {
+	BaseModel
+	AllowanceID uint   `gorm:"index;not null" json:"allowance_id"`
+	UserID      *uint  `gorm:"index" json:"user_id,omitempty"`
+	Grade       string `gorm:"index" json:"grade,omitempty"`
+	Amount      *Money `json:"amount,omitempty"`
+
+	Allowance Allowance `json:"allowance,omitempty"`
+}
+
+// EffectiveAmount is the amount the assignment pays per unit of its basis.
+func (a *AllowanceAssignment) EffectiveAmount() Money {
+	if a.Amount != nil {
+		return *a.Amount
+	}
+	return a.Allowance.Amount
+}
@@ -4,13 +4,18 @@ import "time"
 
 type Attendance struct {
 	BaseModel
-	UserID          uint       `json:"user_id"`
-	Date            time.Time  `json:"date"`
-	CheckIn         time.Time  `json:"check_in"`
-	CheckOut        *time.Time `json:"check_out,omitempty"`
-	WorkingHours    float64    `json:"working_hours"`
-	PayrollPeriodID *uint      `json:"payroll_period_id,omitempty"`
-	IsProcessed     bool       `gorm:"default:false" json:"is_processed"`
+	UserID       uint       `json:"user_id"`
+	Date         time.Time  `json:"date"`
+	CheckIn      time.Time  `json:"check_in"`
+	CheckOut     *time.Time `json:"check_out,omitempty"`
+	WorkingHours float64    `json:"working_hours"`
+	ShiftID      *uint      `json:"shift_id,omitempty"` // shift scheduled on Date
+	// LateMinutes and EarlyLeaveMinutes are measured against the shift,
+	// after its grace periods.
+	LateMinutes       int   `gorm:"not null;default:0" json:"late_minutes"`
+	EarlyLeaveMinutes int   `gorm:"not null;default:0" json:"early_leave_minutes"`
+	PayrollPeriodID   *uint `json:"payroll_period_id,omitempty"`
+	IsProcessed       bool  `gorm:"default:false" json:"is_processed"`
 
 	// Relationships
 	User          User           `json:"user,omitempty"`
@@ -0,0 +1,90 @@
+package model
+
+type ProrationPolicy string
+
+const (
+	// ProrationFixedDivisor pays salary / divisor for every paid day.
+	ProrationFixedDivisor ProrationPolicy = "fixed_divisor"
+	// ProrationWorkingDays pays salary * paid days / working days in the period.
+	ProrationWorkingDays ProrationPolicy = "working_days"
+	// ProrationCalendarDays pays salary * (calendar days - absences) / calendar days.
+	ProrationCalendarDays ProrationPolicy = "calendar_days"
+	// ProrationSalaryMinusAbsences pays the full salary less salary / divisor per absence.
+	ProrationSalaryMinusAbsences ProrationPolicy = "salary_minus_absences"
+)
+
+// DefaultProrationDivisor is the number of working days a monthly salary is
+// divided by when a company does not configure its own.
+const DefaultProrationDivisor = 22
+
+func (p ProrationPolicy) IsValid() bool {
+	switch p {
+	case ProrationFixedDivisor, ProrationWorkingDays, ProrationCalendarDays, ProrationSalaryMinusAbsences:
+		return true
+	}
+	return false
+}
+
+// JKKRiskClass is the work accident (JKK) risk group a company is registered
+// under with BPJS Ketenagakerjaan.
+type JKKRiskClass string
+
+const (
+	JKKRiskVeryLow  JKKRiskClass = "very_low"
+	JKKRiskLow      JKKRiskClass = "low"
+	JKKRiskMedium   JKKRiskClass = "medium"
+	JKKRiskHigh     JKKRiskClass = "high"
+	JKKRiskVeryHigh JKKRiskClass = "very_high"
+)
+
+func (c JKKRiskClass) IsValid() bool {
+	switch c {
+	case JKKRiskVeryLow, JKKRiskLow, JKKRiskMedium, JKKRiskHigh, JKKRiskVeryHigh:
+		return true
+	}
+	return false
+}
+
+// LatenessPolicy decides how late arrivals and early departures are deducted
+// from pay.
+type LatenessPolicy string
+
+const (
+	// LatenessNone records lateness without deducting anything.
+	LatenessNone LatenessPolicy = "none"
+	// LatenessPerMinute deducts the lateness rate for every minute.
+	LatenessPerMinute LatenessPolicy = "per_minute"
+	// LatenessPerIncident deducts the lateness rate for every late arrival
+	// and every early departure.
+	LatenessPerIncident LatenessPolicy = "per_incident"
+	// LatenessHourlyWage deducts the minutes at the hourly wage overtime is
+	// based on.
+	LatenessHourlyWage LatenessPolicy = "hourly_wage"
+)
+
+func (p LatenessPolicy) IsValid() bool {
+	switch p {
+	case LatenessNone, LatenessPerMinute, LatenessPerIncident, LatenessHourlyWage:
+		return true
+	}
+	return false
+}
+
+type Company struct {
+	BaseModel
+	Name             string          `gorm:"uniqueIndex;not null" json:"name"`
+	ProrationPolicy  ProrationPolicy `gorm:"not null;default:fixed_divisor" json:"proration_policy"`
+	ProrationDivisor int             `gorm:"not null;default:22" json:"proration_divisor"`
+	JKKRiskClass     JKKRiskClass    `gorm:"not null;default:very_low" json:"jkk_risk_class"`
+	// NetPayFloor is the lowest net pay deductions may leave an employee with.
+	NetPayFloor Money `gorm:"not null;default:0" json:"net_pay_floor"`
+	// LatenessRate is the amount per minute or per incident, depending on
+	// LatenessPolicy.
+	LatenessPolicy LatenessPolicy `gorm:"not null;default:none" json:"lateness_policy"`
+	LatenessRate   Money          `gorm:"not null;default:0" json:"lateness_rate"`
+	// SubmissionFutureDays is how many days ahead of today attendance,
+	// overtime and reimbursements may be dated.
+	SubmissionFutureDays int `gorm:"not null;default:0" json:"submission_future_days"`
+
+	Users []User `json:"users,omitempty"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// Compensation is one entry of an employee's salary history: Salary applies
+// from EffectiveDate until the next entry takes over.
+type Compensation struct {
+	BaseModel
+	UserID        uint      `gorm:"index;not null" json:"user_id"`
+	Salary        Money     `gorm:"not null" json:"salary"`
+	EffectiveDate time.Time `gorm:"index;not null" json:"effective_date"`
+	Reason        string    `json:"reason,omitempty"`
+}
@@ -0,0 +1,122 @@
+package model
+
+import "time"
+
+type DeductionType string
+
+const (
+	DeductionOneOff    DeductionType = "one_off"
+	DeductionRecurring DeductionType = "recurring"
+	DeductionLoan      DeductionType = "loan"
+)
+
+func (t DeductionType) IsValid() bool {
+	return t == DeductionOneOff || t == DeductionRecurring || t == DeductionLoan
+}
+
+type DeductionStatus string
+
+const (
+	DeductionActive    DeductionStatus = "active"
+	DeductionCompleted DeductionStatus = "completed"
+	DeductionCancelled DeductionStatus = "cancelled"
+)
+
+type Deduction struct {
+	BaseModel
+	UserID      uint          `gorm:"index;not null" json:"user_id"`
+	Type        DeductionType `gorm:"not null" json:"type"`
+	Description string        `json:"description"`
+	// Amount is the one-off amount, the recurring amount or the loan installment.
+	Amount Money `gorm:"not null" json:"amount"`
+	// Principal is the amount lent; loans only.
+	Principal Money `json:"principal"`
+	// InstallmentCount is the number of loan installments, or the number of
+	// recurring periods (0 = until cancelled).
+	InstallmentCount    int `json:"installment_count"`
+	InstallmentsCharged int `gorm:"not null;default:0" json:"installments_charged"`
+	// RemainingBalance is what is still owed on a loan or one-off deduction.
+	RemainingBalance Money `json:"remaining_balance"`
+	// Carryover is the shortfall of earlier periods that the net pay floor
+	// prevented from being deducted; it is added to the next amount due.
+	Carryover Money           `gorm:"not null;default:0" json:"carryover"`
+	StartDate time.Time       `json:"start_date"`
+	Status    DeductionStatus `gorm:"index;not null;default:active" json:"status"`
+
+	// Relationships
+	User         *User                  `json:"user,omitempty"`
+	Transactions []DeductionTransaction `json:"transactions,omitempty"`
+}
+
+// AmountDue is what the deduction asks for in the next payroll period,
+// including any carried over shortfall.
+func (d *Deduction) AmountDue() Money {
+	if d.Status != DeductionActive {
+		return 0
+	}
+
+	switch d.Type {
+	case DeductionOneOff:
+		return d.RemainingBalance
+	case DeductionLoan:
+		if d.InstallmentsCharged+1 >= d.InstallmentCount {
+			return d.RemainingBalance // the last installment settles the rest
+		}
+		return (d.Amount + d.Carryover).Min(d.RemainingBalance)
+	default:
+		if d.InstallmentCount > 0 && d.InstallmentsCharged >= d.InstallmentCount {
+			return d.Carryover
+		}
+		return d.Amount + d.Carryover
+	}
+}
+
+// Apply records the outcome of one payroll period on the deduction.
+func (d *Deduction) Apply(transaction *DeductionTransaction) {
+	d.Carryover = transaction.Shortfall
+	if d.Type == DeductionLoan || d.Type == DeductionOneOff {
+		d.RemainingBalance -= transaction.AppliedAmount
+	}
+	if d.Type != DeductionOneOff {
+		d.InstallmentsCharged++
+	}
+
+	switch d.Type {
+	case DeductionOneOff, DeductionLoan:
+		if d.RemainingBalance <= 0 {
+			d.Status = DeductionCompleted
+		}
+	case DeductionRecurring:
+		if d.InstallmentCount > 0 && d.InstallmentsCharged >= d.InstallmentCount && d.Carryover == 0 {
+			d.Status = DeductionCompleted
+		}
+	}
+}
+
+// Revert undoes Apply for a transaction of a reversed payroll run.
+func (d *Deduction) Revert(transaction *DeductionTransaction) {
+	d.Carryover = transaction.CarryoverBefore
+	if d.Type == DeductionLoan || d.Type == DeductionOneOff {
+		d.RemainingBalance += transaction.AppliedAmount
+	}
+	if d.Type != DeductionOneOff {
+		d.InstallmentsCharged--
+	}
+	if d.Status == DeductionCompleted {
+		d.Status = DeductionActive
+	}
+}
+
+// DeductionTransaction is what one payslip deducted for a deduction.
+type DeductionTransaction struct {
+	BaseModel
+	DeductionID     uint  `gorm:"index;not null" json:"deduction_id"`
+	PayslipID       uint  `gorm:"index;not null" json:"payslip_id"`
+	PayrollPeriodID uint  `gorm:"index;not null" json:"payroll_period_id"`
+	DueAmount       Money `json:"due_amount"`
+	AppliedAmount   Money `json:"applied_amount"`
+	Shortfall       Money `json:"shortfall"`
+	// CarryoverBefore is the deduction's carryover before this transaction,
+	// kept so a reversed run can restore it.
+	CarryoverBefore Money `json:"carryover_before"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+type HolidayType string
+
+const (
+	HolidayPublic          HolidayType = "public_holiday"
+	HolidayCollectiveLeave HolidayType = "collective_leave"
+)
+
+func (t HolidayType) IsValid() bool {
+	return t == HolidayPublic || t == HolidayCollectiveLeave
+}
+
+type Holiday struct {
+	BaseModel
+	Date time.Time   `gorm:"index;not null" json:"date"`
+	Name string      `gorm:"not null" json:"name"`
+	Type HolidayType `gorm:"not null;default:public_holiday" json:"type"`
+}
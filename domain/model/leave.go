@@ -0,0 +1,110 @@
+package model
+
+import "time"
+
+// LeaveType is a kind of leave employees can request. Days on a Paid leave
+// type count as paid days in payroll; other leave days are absences.
+//
+// A leave type with AccrualDaysPerYear keeps a yearly balance per employee,
+// earned monthly once ServiceMonthsRequired months have been served. At
+// year end up to CarryOverCap unused days move to the next year and expire
+// CarryOverExpiryMonths into it; the rest expire. PayOutOnTermination pays
+// the remaining balance with the final salary.
+type LeaveType struct {
+	BaseModel
+	Code     string `gorm:"uniqueIndex;not null" json:"code"`
+	Name     string `gorm:"not null" json:"name"`
+	Paid     bool   `gorm:"not null" json:"paid"`
+	IsActive bool   `gorm:"not null" json:"is_active"`
+
+	AccrualDaysPerYear    float64 `gorm:"not null;default:0" json:"accrual_days_per_year"`
+	ServiceMonthsRequired int     `gorm:"not null;default:0" json:"service_months_required"`
+	CarryOverCap          float64 `gorm:"not null;default:0" json:"carry_over_cap"`
+	CarryOverExpiryMonths int     `gorm:"not null;default:0" json:"carry_over_expiry_months"` // 0 keeps carried days all year
+	PayOutOnTermination   bool    `gorm:"not null;default:false" json:"pay_out_on_termination"`
+}
+
+// TracksBalance reports whether requests of this type draw on a balance.
+func (t *LeaveType) TracksBalance() bool {
+	return t.AccrualDaysPerYear > 0
+}
+
+type LeaveStatus string
+
+const (
+	LeavePending   LeaveStatus = "pending"
+	LeaveApproved  LeaveStatus = "approved"
+	LeaveRejected  LeaveStatus = "rejected"
+	LeaveCancelled LeaveStatus = "cancelled"
+)
+
+// LeaveRequest asks for leave from StartDate to EndDate inclusive. Days is
+// the number of scheduled working days the leave covers.
+type LeaveRequest struct {
+	BaseModel
+	UserID      uint        `gorm:"index;not null" json:"user_id"`
+	LeaveTypeID uint        `gorm:"index;not null" json:"leave_type_id"`
+	StartDate   time.Time   `gorm:"not null" json:"start_date"`
+	EndDate     time.Time   `gorm:"not null" json:"end_date"`
+	Days        int         `gorm:"not null" json:"days"`
+	Reason      string      `json:"reason"`
+	Status      LeaveStatus `gorm:"index;not null;default:pending" json:"status"`
+	ReviewedBy  *uint       `json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time  `json:"reviewed_at,omitempty"`
+	ReviewNote  string      `json:"review_note,omitempty"`
+
+	// Relationships
+	User      User      `json:"user,omitempty"`
+	LeaveType LeaveType `json:"leave_type"`
+}
+
+// Covers reports whether date falls within the leave.
+func (l *LeaveRequest) Covers(date time.Time) bool {
+	key := date.Format("2006-01-02")
+	return key >= l.StartDate.Format("2006-01-02") && key <= l.EndDate.Format("2006-01-02")
+}
+
+// LeaveBalance is an employee's balance of one leave type for one calendar
+// year. Amounts are in days.
+type LeaveBalance struct {
+	BaseModel
+	UserID      uint `gorm:"uniqueIndex:idx_leave_balance;not null" json:"user_id"`
+	LeaveTypeID uint `gorm:"uniqueIndex:idx_leave_balance;not null" json:"leave_type_id"`
+	Year        int  `gorm:"uniqueIndex:idx_leave_balance;not null" json:"year"`
+
+	Accrued            float64    `gorm:"not null;default:0" json:"accrued"`
+	CarriedOver        float64    `gorm:"not null;default:0" json:"carried_over"`
+	CarryOverExpiresAt *time.Time `json:"carry_over_expires_at,omitempty"`
+	CarryOverLapsed    bool       `gorm:"not null;default:false" json:"carry_over_lapsed"`
+	Used               float64    `gorm:"not null;default:0" json:"used"`
+	Expired            float64    `gorm:"not null;default:0" json:"expired"`
+	PaidOut            float64    `gorm:"not null;default:0" json:"paid_out"`
+	CarriedForward     float64    `gorm:"not null;default:0" json:"carried_forward"` // moved to the next year
+
+	LeaveType LeaveType `json:"leave_type"`
+}
+
+// Available is the number of days that can still be taken.
+func (b *LeaveBalance) Available() float64 {
+	return b.Accrued + b.CarriedOver - b.Used - b.Expired - b.PaidOut - b.CarriedForward
+}
+
+// LapseCarryOver expires the carried over days not used by the time they
+// expire. Leave taken in the year uses carried over days first.
+func (b *LeaveBalance) LapseCarryOver(asOf time.Time) {
+	if b.CarryOverExpiresAt == nil || b.CarryOverLapsed || asOf.Before(*b.CarryOverExpiresAt) {
+		return
+	}
+	b.Expired += max(b.CarriedOver-b.Used, 0)
+	b.CarryOverLapsed = true
+}
+
+// CloseYear ends the balance's year: up to carryOverCap available days are
+// carried forward into the next year and the rest expire.
+func (b *LeaveBalance) CloseYear(carryOverCap float64) (carryOver float64) {
+	available := max(b.Available(), 0)
+	carryOver = min(available, carryOverCap)
+	b.CarriedForward += carryOver
+	b.Expired += available - carryOver
+	return carryOver
+}
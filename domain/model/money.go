@@ -0,0 +1,94 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Currency is the currency every Money amount is denominated in.
+const Currency = "IDR"
+
+// Money is an amount in whole rupiah. Amounts are kept as integers so payslip
+// lines and totals reconcile exactly with bank transfer files.
+//
+// Rounding rule: any operation that produces a fraction of a rupiah rounds
+// half away from zero, once, at the point the amount is produced. Totals are
+// sums of already rounded amounts and are never rounded again.
+type Money int64
+
+// MulDiv returns m * num / den rounded half away from zero. The intermediate
+// product is computed with arbitrary precision so it cannot overflow.
+func (m Money) MulDiv(num, den int64) Money {
+	if den == 0 {
+		panic("model: Money.MulDiv division by zero")
+	}
+	product := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(num))
+	divisor := big.NewInt(den)
+	if divisor.Sign() < 0 {
+		product.Neg(product)
+		divisor.Neg(divisor)
+	}
+
+	quotient, remainder := new(big.Int).QuoRem(product, divisor, new(big.Int))
+	// |remainder| * 2 >= divisor means the fraction is at least one half.
+	if remainder.Abs(remainder).Lsh(remainder, 1).Cmp(divisor) >= 0 {
+		if product.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return Money(quotient.Int64())
+}
+
+// Percent returns m scaled by a rate expressed in basis points
+// (1 bp = 0.01%), rounded half away from zero.
+func (m Money) Percent(basisPoints int64) Money {
+	return m.MulDiv(basisPoints, 10_000)
+}
+
+// MulHours returns m multiplied by a number of hours. Hours are first
+// converted to whole minutes so the multiplication stays exact.
+func (m Money) MulHours(hours float64) Money {
+	return m.MulDiv(HoursToMinutes(hours), 60)
+}
+
+func (m Money) Min(other Money) Money {
+	if other < m {
+		return other
+	}
+	return m
+}
+
+func (m Money) Max(other Money) Money {
+	if other > m {
+		return other
+	}
+	return m
+}
+
+// String formats m the way amounts are printed on payslips, e.g. "IDR 1.250.000".
+func (m Money) String() string {
+	digits := fmt.Sprintf("%d", int64(m))
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(r)
+	}
+	return sign + Currency + " " + b.String()
+}
+
+// HoursToMinutes converts fractional hours to whole minutes, rounding to the
+// nearest minute.
+func HoursToMinutes(hours float64) int64 {
+	return int64(math.Round(hours * 60))
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+type PayFrequency string
+
+const (
+	PayMonthly     PayFrequency = "monthly"
+	PaySemiMonthly PayFrequency = "semi_monthly"
+	PayBiWeekly    PayFrequency = "bi_weekly"
+	PayWeekly      PayFrequency = "weekly"
+)
+
+func (f PayFrequency) IsValid() bool {
+	switch f {
+	case PayMonthly, PaySemiMonthly, PayBiWeekly, PayWeekly:
+		return true
+	}
+	return false
+}
+
+// PeriodsPerYear is the number of pay periods in a year.
+func (f PayFrequency) PeriodsPerYear() int {
+	switch f {
+	case PaySemiMonthly:
+		return 24
+	case PayBiWeekly:
+		return 26
+	case PayWeekly:
+		return 52
+	}
+	return 12
+}
+
+// PayGroup is a set of employees paid together on the same frequency.
+// Monthly periods are calendar months and semi-monthly ones split them on
+// the 15th; weekly and bi-weekly periods are counted from AnchorDate.
+type PayGroup struct {
+	BaseModel
+	Name       string       `gorm:"uniqueIndex;not null" json:"name"`
+	Frequency  PayFrequency `gorm:"not null" json:"frequency"`
+	AnchorDate time.Time    `gorm:"not null" json:"anchor_date"`
+	IsActive   bool         `gorm:"not null" json:"is_active"`
+}
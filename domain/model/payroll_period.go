@@ -2,16 +2,94 @@ package model
 
 import "time"
 
+type PeriodStatus string
+
+const (
+	// PeriodDraft periods are planned but take no submissions yet.
+	PeriodDraft PeriodStatus = "draft"
+	// PeriodOpen periods take attendance, overtime and reimbursements.
+	PeriodOpen PeriodStatus = "open"
+	// PeriodLocked periods are frozen for review before payroll runs.
+	PeriodLocked    PeriodStatus = "locked"
+	PeriodProcessed PeriodStatus = "processed"
+	PeriodPaid      PeriodStatus = "paid"
+	// PeriodClosed periods are final; they can no longer be corrected.
+	PeriodClosed PeriodStatus = "closed"
+)
+
+// periodTransitions lists the statuses each status may move to.
+var periodTransitions = map[PeriodStatus][]PeriodStatus{
+	PeriodDraft:     {PeriodOpen},
+	PeriodOpen:      {PeriodDraft, PeriodLocked, PeriodProcessed},
+	PeriodLocked:    {PeriodOpen, PeriodProcessed},
+	PeriodProcessed: {PeriodOpen, PeriodPaid}, // reopened by reversing the run
+	PeriodPaid:      {PeriodClosed},
+}
+
+func (s PeriodStatus) IsValid() bool {
+	switch s {
+	case PeriodDraft, PeriodOpen, PeriodLocked, PeriodProcessed, PeriodPaid, PeriodClosed:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether a period in status s may move to next.
+func (s PeriodStatus) CanTransitionTo(next PeriodStatus) bool {
+	for _, allowed := range periodTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Processed reports whether payroll has run for a period in status s.
+func (s PeriodStatus) Processed() bool {
+	return s == PeriodProcessed || s == PeriodPaid || s == PeriodClosed
+}
+
 type PayrollPeriod struct {
 	BaseModel
-	StartDate   time.Time  `json:"start_date"`
-	EndDate     time.Time  `json:"end_date"`
+	// PayGroupID is the pay group the period pays. Periods without one pay
+	// the employees who are not in a pay group.
+	PayGroupID *uint        `gorm:"index" json:"pay_group_id,omitempty"`
+	StartDate  time.Time    `json:"start_date"`
+	EndDate    time.Time    `json:"end_date"`
+	Status     PeriodStatus `gorm:"index;not null;default:open" json:"status"`
+	// IsProcessed and IsPaid follow Status; see SetStatus.
 	IsProcessed bool       `gorm:"default:false" json:"is_processed"`
 	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+	IsPaid      bool       `gorm:"default:false" json:"is_paid"`
+	PaidAt      *time.Time `json:"paid_at,omitempty"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
 
 	// Relationships
+	PayGroup       *PayGroup       `json:"pay_group,omitempty"`
 	Attendances    []Attendance    `json:"attendances,omitempty"`
 	Overtimes      []Overtime      `json:"overtimes,omitempty"`
 	Reimbursements []Reimbursement `json:"reimbursements,omitempty"`
 	Payslips       []Payslip       `json:"payslips,omitempty"`
 }
+
+// SetStatus moves the period to status at the given time and keeps the
+// processed and paid flags and timestamps in step with it. It does not
+// check the transition; see PeriodStatus.CanTransitionTo.
+func (p *PayrollPeriod) SetStatus(status PeriodStatus, at time.Time) {
+	p.Status = status
+	p.IsProcessed = status.Processed()
+	p.IsPaid = status == PeriodPaid || status == PeriodClosed
+
+	switch status {
+	case PeriodProcessed:
+		p.ProcessedAt = &at
+		p.PaidAt = nil
+	case PeriodPaid:
+		p.PaidAt = &at
+	case PeriodClosed:
+		p.ClosedAt = &at
+	default:
+		p.ProcessedAt = nil
+		p.PaidAt = nil
+	}
+}
@@ -1,18 +1,66 @@
 package model
 
+import "time"
+
+type PayslipStatus string
+
+const (
+	PayslipActive PayslipStatus = "active"
+	// PayslipVoided payslips belong to a reversed payroll run and are kept
+	// for history only.
+	PayslipVoided PayslipStatus = "voided"
+)
+
+type PayslipKind string
+
+const (
+	PayslipRegular PayslipKind = "regular"
+	// PayslipAdjustment payslips come from a correction run and carry only
+	// the difference with the payslips already issued for the period.
+	PayslipAdjustment PayslipKind = "adjustment"
+)
+
 type Payslip struct {
 	BaseModel
-	UserID             uint    `json:"user_id"`
-	PayrollPeriodID    uint    `json:"payroll_period_id"`
-	BaseSalary         float64 `json:"base_salary"`
-	WorkingDays        int     `json:"working_days"`
-	AttendanceDays     int     `json:"attendance_days"`
-	OvertimeHours      float64 `json:"overtime_hours"`
-	OvertimePay        float64 `json:"overtime_pay"`
-	ReimbursementTotal float64 `json:"reimbursement_total"`
-	TotalPay           float64 `json:"total_pay"`
+	UserID             uint            `json:"user_id"`
+	PayrollPeriodID    uint            `json:"payroll_period_id"`
+	Kind               PayslipKind     `gorm:"index;not null;default:regular" json:"kind"`
+	OriginalPayslipID  *uint           `gorm:"index" json:"original_payslip_id,omitempty"`
+	CorrectionReason   string          `json:"correction_reason,omitempty"`
+	Status             PayslipStatus   `gorm:"index;not null;default:active" json:"status"`
+	VoidedAt           *time.Time      `json:"voided_at,omitempty"`
+	BaseSalary         Money           `json:"base_salary"`
+	WorkingDays        int             `json:"working_days"`
+	AttendanceDays     int             `json:"attendance_days"`
+	PaidLeaveDays      int             `gorm:"not null;default:0" json:"paid_leave_days"`
+	ProrationPolicy    ProrationPolicy `json:"proration_policy"`
+	LateMinutes        int             `gorm:"not null;default:0" json:"late_minutes"`
+	EarlyLeaveMinutes  int             `gorm:"not null;default:0" json:"early_leave_minutes"`
+	OvertimeHours      float64         `json:"overtime_hours"`
+	OvertimePay        Money           `json:"overtime_pay"`
+	ReimbursementTotal Money           `json:"reimbursement_total"`
+	TotalEarnings      Money           `json:"total_earnings"`
+	TotalDeductions    Money           `json:"total_deductions"`
+	TaxableIncome      Money           `json:"taxable_income"`
+	TaxDeductible      Money           `json:"tax_deductible"`
+	TaxAmount          Money           `json:"tax_amount"`
+	EmployerCost       Money           `json:"employer_cost"`
+	TotalPay           Money           `json:"total_pay"`
 
 	// Relationships
 	User          User          `json:"user,omitempty"`
 	PayrollPeriod PayrollPeriod `json:"payroll_period,omitempty"`
+	Items         []PayslipItem `json:"items,omitempty"`
+	// Records are the attendance, overtime and reimbursement records the
+	// payslip paid.
+	Records []PayslipRecord `json:"records,omitempty"`
+	// SalarySegments are the salaries base pay was calculated with.
+	SalarySegments []PayslipSalarySegment `json:"salary_segments,omitempty"`
+
+	DeductionTransactions []DeductionTransaction `json:"deduction_transactions,omitempty"`
+
+	// LeaveBalances are the leave balances a payroll run has to save with
+	// the payslip: the balances its leave payout lines pay out, brought up
+	// to the termination date, and the previous year balances they close.
+	LeaveBalances []*LeaveBalance `gorm:"-" json:"-"`
 }
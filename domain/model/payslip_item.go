@@ -0,0 +1,54 @@
+package model
+
+type PayslipItemType string
+
+const (
+	PayslipItemEarning   PayslipItemType = "earning"
+	PayslipItemDeduction PayslipItemType = "deduction"
+	// PayslipItemEmployerCost lines are paid by the employer on top of the
+	// employee's pay and do not change net pay.
+	PayslipItemEmployerCost PayslipItemType = "employer_cost"
+)
+
+// Codes of the line items produced by the built-in pay components.
+const (
+	PayCodeBasePay       = "BASE_PAY"
+	PayCodeOvertime      = "OVERTIME"
+	PayCodeAllowance     = "ALLOWANCE"
+	PayCodeRetro         = "RETRO"
+	PayCodeReimbursement = "REIMBURSEMENT"
+	PayCodeIncomeTax     = "PPH21"
+	PayCodeDeduction     = "DEDUCTION"
+	PayCodeLateness      = "LATENESS"
+	PayCodeLeavePayout   = "LEAVE_PAYOUT"
+
+	PayCodeBPJSHealthEmployee  = "BPJS_KES_EE"
+	PayCodeBPJSHealthEmployer  = "BPJS_KES_ER"
+	PayCodeBPJSJHTEmployee     = "BPJS_JHT_EE"
+	PayCodeBPJSJHTEmployer     = "BPJS_JHT_ER"
+	PayCodeBPJSPensionEmployee = "BPJS_JP_EE"
+	PayCodeBPJSPensionEmployer = "BPJS_JP_ER"
+	PayCodeBPJSJKK             = "BPJS_JKK"
+	PayCodeBPJSJKM             = "BPJS_JKM"
+)
+
+type PayslipItem struct {
+	BaseModel
+	PayslipID uint            `gorm:"index;not null" json:"payslip_id"`
+	Code      string          `gorm:"not null" json:"code"`
+	Label     string          `json:"label"`
+	Type      PayslipItemType `gorm:"not null" json:"type"`
+	Quantity  float64         `json:"quantity"`
+	Rate      Money           `json:"rate"`
+	Amount    Money           `json:"amount"`
+
+	// Taxable marks income counted in the PPh 21 gross; TaxDeductible marks
+	// employee contributions subtracted from annual net income.
+	Taxable       bool `gorm:"not null;default:false" json:"taxable"`
+	TaxDeductible bool `gorm:"not null;default:false" json:"tax_deductible"`
+
+	// RetroPeriodID is the earlier period a retro line pays back pay for.
+	RetroPeriodID *uint `gorm:"index" json:"retro_period_id,omitempty"`
+	// LeaveBalanceID is the leave balance a leave payout line pays out.
+	LeaveBalanceID *uint `gorm:"index" json:"leave_balance_id,omitempty"`
+}
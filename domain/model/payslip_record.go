@@ -0,0 +1,29 @@
+package model
+
+type PayslipRecordType string
+
+const (
+	PayslipRecordAttendance    PayslipRecordType = "attendance"
+	PayslipRecordOvertime      PayslipRecordType = "overtime"
+	PayslipRecordReimbursement PayslipRecordType = "reimbursement"
+)
+
+// PayslipRecord names one attendance, overtime or reimbursement record a
+// payslip paid.
+type PayslipRecord struct {
+	BaseModel
+	PayslipID  uint              `gorm:"index;not null" json:"payslip_id"`
+	RecordType PayslipRecordType `gorm:"index:idx_payslip_record;not null" json:"record_type"`
+	RecordID   uint              `gorm:"index:idx_payslip_record;not null" json:"record_id"`
+}
+
+// RecordIDs returns the IDs of the records of recordType the payslip paid.
+func (p *Payslip) RecordIDs(recordType PayslipRecordType) []uint {
+	ids := make([]uint, 0)
+	for _, record := range p.Records {
+		if record.RecordType == recordType {
+			ids = append(ids, record.RecordID)
+		}
+	}
+	return ids
+}
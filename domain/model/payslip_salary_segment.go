@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// PayslipSalarySegment is one stretch of a payslip's period paid at one
+// salary. Retro pay compares them with the salary history to find what an
+// earlier payslip underpaid.
+type PayslipSalarySegment struct {
+	BaseModel
+	PayslipID uint      `gorm:"index;not null" json:"payslip_id"`
+	StartDate time.Time `gorm:"not null" json:"start_date"`
+	EndDate   time.Time `gorm:"not null" json:"end_date"`
+	Salary    Money     `gorm:"not null" json:"salary"`
+}
@@ -1,12 +1,17 @@
 package model
 
+import "time"
+
 type Reimbursement struct {
 	BaseModel
-	UserID          uint    `json:"user_id"`
-	Amount          float64 `json:"amount"`
-	Description     string  `json:"description"`
-	PayrollPeriodID *uint   `json:"payroll_period_id,omitempty"`
-	IsProcessed     bool    `gorm:"default:false" json:"is_processed"`
+	UserID uint `json:"user_id"`
+	// Date is the day the expense was made; payroll pays it in the period
+	// holding that day.
+	Date            time.Time `gorm:"index" json:"date"`
+	Amount          Money     `json:"amount"`
+	Description     string    `json:"description"`
+	PayrollPeriodID *uint     `json:"payroll_period_id,omitempty"`
+	IsProcessed     bool      `gorm:"default:false" json:"is_processed"`
 
 	// Relationships
 	User          User           `json:"user,omitempty"`
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Shift is a daily working time. StartTime and EndTime are "15:04" clock
+// times; an EndTime earlier than StartTime ends the next day.
+type Shift struct {
+	BaseModel
+	Code         string `gorm:"uniqueIndex;not null" json:"code"`
+	Name         string `gorm:"not null" json:"name"`
+	StartTime    string `gorm:"not null" json:"start_time"`
+	EndTime      string `gorm:"not null" json:"end_time"`
+	BreakMinutes int    `gorm:"not null;default:0" json:"break_minutes"`
+	// Arriving up to LateGraceMinutes after the start, or leaving up to
+	// EarlyLeaveGraceMinutes before the end, is not counted.
+	LateGraceMinutes       int `gorm:"not null;default:0" json:"late_grace_minutes"`
+	EarlyLeaveGraceMinutes int `gorm:"not null;default:0" json:"early_leave_grace_minutes"`
+}
+
+// Times returns the start and end of the shift worked on date.
+func (s *Shift) Times(date time.Time) (start, end time.Time, err error) {
+	startClock, err := time.Parse("15:04", s.StartTime)
+	if err != nil {
+		return start, end, fmt.Errorf("invalid shift start time %q", s.StartTime)
+	}
+	endClock, err := time.Parse("15:04", s.EndTime)
+	if err != nil {
+		return start, end, fmt.Errorf("invalid shift end time %q", s.EndTime)
+	}
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	start = day.Add(time.Duration(startClock.Hour())*time.Hour + time.Duration(startClock.Minute())*time.Minute)
+	end = day.Add(time.Duration(endClock.Hour())*time.Hour + time.Duration(endClock.Minute())*time.Minute)
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1) // overnight shift
+	}
+	return start, end, nil
+}
+
+// ExpectedHours is the paid working time of the shift, breaks excluded.
+func (s *Shift) ExpectedHours() float64 {
+	start, end, err := s.Times(time.Time{})
+	if err != nil {
+		return 0
+	}
+	return max(end.Sub(start).Hours()-float64(s.BreakMinutes)/60, 0)
+}
+
+// Punctuality returns the minutes checkIn is after the start and checkOut
+// before the end of the shift worked on date. Minutes within the grace
+// period are forgiven; past it the whole delay counts. A missing checkOut is
+// not an early departure.
+func (s *Shift) Punctuality(date, checkIn time.Time, checkOut *time.Time) (lateMinutes, earlyLeaveMinutes int, err error) {
+	start, end, err := s.Times(date)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if late := int(checkIn.Sub(start).Minutes()); late > s.LateGraceMinutes {
+		lateMinutes = late
+	}
+	if checkOut != nil {
+		if early := int(end.Sub(*checkOut).Minutes()); early > s.EarlyLeaveGraceMinutes {
+			earlyLeaveMinutes = early
+		}
+	}
+	return lateMinutes, earlyLeaveMinutes, nil
+}
+
+// WorkSchedule is a repeating pattern of shifts. Day 0 of the cycle falls on
+// AnchorDate; a weekly schedule has CycleDays 7 and a Monday anchor, a
+// rotating one any cycle length. Cycle days without a shift are rest days.
+type WorkSchedule struct {
+	BaseModel
+	Name       string            `gorm:"uniqueIndex;not null" json:"name"`
+	CycleDays  int               `gorm:"not null;default:7" json:"cycle_days"`
+	AnchorDate time.Time         `gorm:"not null" json:"anchor_date"`
+	Days       []WorkScheduleDay `json:"days,omitempty"`
+}
+
+type WorkScheduleDay struct {
+	BaseModel
+	WorkScheduleID uint  `gorm:"index;not null" json:"work_schedule_id"`
+	DayIndex       int   `gorm:"not null" json:"day_index"`
+	ShiftID        uint  `gorm:"not null" json:"shift_id"`
+	Shift          Shift `json:"shift"`
+}
+
+// ShiftOn returns the shift scheduled on date, or nil on a rest day.
+func (w *WorkSchedule) ShiftOn(date time.Time) *Shift {
+	if w.CycleDays <= 0 {
+		return nil
+	}
+	anchor := time.Date(w.AnchorDate.Year(), w.AnchorDate.Month(), w.AnchorDate.Day(), 0, 0, 0, 0, time.UTC)
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	offset := int(day.Sub(anchor).Hours() / 24)
+	index := (offset%w.CycleDays + w.CycleDays) % w.CycleDays
+
+	for i := range w.Days {
+		if w.Days[i].DayIndex == index {
+			return &w.Days[i].Shift
+		}
+	}
+	return nil
+}
+
+// ScheduleAssignment puts an employee on a work schedule from EffectiveDate
+// until their next assignment.
+type ScheduleAssignment struct {
+	BaseModel
+	UserID         uint         `gorm:"index;not null" json:"user_id"`
+	WorkScheduleID uint         `gorm:"index;not null" json:"work_schedule_id"`
+	EffectiveDate  time.Time    `gorm:"not null" json:"effective_date"`
+	WorkSchedule   WorkSchedule `json:"work_schedule,omitempty"`
+}
@@ -0,0 +1,40 @@
+package model
+
+// TaxPTKP is the annual non-taxable income (PTKP) of a tax status such as
+// "K/1", and the TER category monthly withholding uses for that status.
+type TaxPTKP struct {
+	BaseModel
+	EffectiveYear int    `gorm:"index;not null" json:"effective_year"`
+	Status        string `gorm:"not null" json:"status"`
+	Amount        Money  `gorm:"not null" json:"amount"`
+	TERCategory   string `gorm:"not null" json:"ter_category"`
+}
+
+// TaxTERRate is one row of the monthly effective rate (TER) tables: gross
+// monthly income up to MaxIncome is taxed at RateBasisPoints. The last row
+// of a category has MaxIncome 0 and covers everything above.
+type TaxTERRate struct {
+	BaseModel
+	EffectiveYear   int    `gorm:"index;not null" json:"effective_year"`
+	Category        string `gorm:"not null" json:"category"`
+	MaxIncome       Money  `json:"max_income"`
+	RateBasisPoints int64  `json:"rate_basis_points"`
+}
+
+// TaxBracket is one layer of the progressive annual rates used for the
+// December reconciliation. The last bracket has MaxIncome 0.
+type TaxBracket struct {
+	BaseModel
+	EffectiveYear   int   `gorm:"index;not null" json:"effective_year"`
+	MaxIncome       Money `json:"max_income"`
+	RateBasisPoints int64 `json:"rate_basis_points"`
+}
+
+// TaxTable bundles the PPh 21 tables in force from EffectiveYear until a
+// newer table is loaded. The bundle itself is not persisted, its rows are.
+type TaxTable struct {
+	EffectiveYear int          `json:"effective_year"`
+	PTKP          []TaxPTKP    `json:"ptkp"`
+	TERRates      []TaxTERRate `json:"ter_rates"`
+	Brackets      []TaxBracket `json:"brackets"`
+}
@@ -1,5 +1,10 @@
 package model
 
+import (
+	"fmt"
+	"time"
+)
+
 type Role string
 
 const (
@@ -7,15 +12,77 @@ const (
 	RoleEmployee Role = "employee"
 )
 
+type EmploymentStatus string
+
+const (
+	EmploymentActive     EmploymentStatus = "active"
+	EmploymentOnLeave    EmploymentStatus = "on_leave"
+	EmploymentTerminated EmploymentStatus = "terminated"
+)
+
+func (s EmploymentStatus) IsValid() bool {
+	return s == EmploymentActive || s == EmploymentOnLeave || s == EmploymentTerminated
+}
+
 type User struct {
 	BaseModel
-	Username string  `gorm:"uniqueIndex;not null"`
-	Password string  `gorm:"not null"`
-	Salary   float64 `gorm:"not null"`
-	Role     Role    `gorm:"not null"`
+	Username string `gorm:"uniqueIndex;not null"`
+	Password string `gorm:"not null"`
+	// Salary is the starting salary. Once an employee has a compensation
+	// history, payroll pays what the history says is in effect.
+	Salary Money `gorm:"not null"`
+	Role   Role  `gorm:"not null"`
+
+	// Employment
+	EmploymentStatus EmploymentStatus `gorm:"not null;default:active" json:"employment_status"`
+	HireDate         *time.Time       `json:"hire_date,omitempty"`
+	TerminationDate  *time.Time       `json:"termination_date,omitempty"` // last day employed
+
+	// Payroll settings
+	CompanyID       *uint           `json:"company_id,omitempty"`
+	PayGroupID      *uint           `gorm:"index" json:"pay_group_id,omitempty"`
+	Grade           string          `gorm:"index" json:"grade,omitempty"`
+	ProrationPolicy ProrationPolicy `json:"proration_policy,omitempty"` // overrides the company policy when set
+
+	// Income tax (PPh 21) status
+	TaxMarried    bool   `gorm:"not null;default:false" json:"tax_married"`
+	TaxDependents int    `gorm:"not null;default:0" json:"tax_dependents"`
+	NPWP          string `json:"npwp,omitempty"`
+
+	Company  *Company  `json:"company,omitempty"`
+	PayGroup *PayGroup `json:"pay_group,omitempty"`
 
 	Attendances    []Attendance    `json:"attendances,omitempty"`
 	Overtimes      []Overtime      `json:"overtimes,omitempty"`
 	Reimbursements []Reimbursement `json:"reimbursements,omitempty"`
 	Payslips       []Payslip       `json:"payslips,omitempty"`
 }
+
+// MaxTaxDependents is the number of dependents PTKP recognises at most.
+const MaxTaxDependents = 3
+
+// PTKPStatus returns the tax status code, e.g. "TK/0" or "K/2".
+func (u *User) PTKPStatus() string {
+	marital := "TK"
+	if u.TaxMarried {
+		marital = "K"
+	}
+	return fmt.Sprintf("%s/%d", marital, min(max(u.TaxDependents, 0), MaxTaxDependents))
+}
+
+func (u *User) HasNPWP() bool {
+	return u.NPWP != ""
+}
+
+// EmploymentWindow narrows [startDate, endDate] to the days the user was
+// employed. ok is false when the user was not employed on any of them.
+func (u *User) EmploymentWindow(startDate, endDate time.Time) (from, to time.Time, ok bool) {
+	from, to = startDate, endDate
+	if u.HireDate != nil && u.HireDate.After(from) {
+		from = *u.HireDate
+	}
+	if u.TerminationDate != nil && u.TerminationDate.Before(to) {
+		to = *u.TerminationDate
+	}
+	return from, to, !from.After(to)
+}
@@ -50,13 +50,32 @@ func main() {
 	reimbursementRepo := repositories.NewReimbursementRepository(db)
 	payrollRepo := repositories.NewPayrollRepository(db)
 	auditRepo := repositories.NewAuditRepository(db)
+	companyRepo := repositories.NewCompanyRepository(db)
+	holidayRepo := repositories.NewHolidayRepository(db)
+	taxRepo := repositories.NewTaxRepository(db)
+	deductionRepo := repositories.NewDeductionRepository(db)
+	allowanceRepo := repositories.NewAllowanceRepository(db)
+	compensationRepo := repositories.NewCompensationRepository(db)
+	scheduleRepo := repositories.NewScheduleRepository(db)
+	leaveRepo := repositories.NewLeaveRepository(db)
+	payGroupRepo := repositories.NewPayGroupRepository(db)
+	transactor := repositories.NewTransactor(db)
 
 	// Initialize use cases
 	userUsecase := usecase.NewUserUsecase(userRepo, auditRepo)
-	attendanceUsecase := usecase.NewAttendanceUsecase(attendanceRepo, auditRepo)
-	overtimeUsecase := usecase.NewOvertimeUsecase(overtimeRepo, auditRepo)
-	reimbursementUsecase := usecase.NewReimbursementUsecase(reimbursementRepo, auditRepo)
-	payrollUsecase := usecase.NewPayrollUsecase(payrollRepo, userRepo, attendanceRepo, overtimeRepo, reimbursementRepo, auditRepo)
+	attendanceUsecase := usecase.NewAttendanceUsecase(attendanceRepo, holidayRepo, scheduleRepo, leaveRepo, userRepo, payrollRepo, auditRepo)
+	overtimeUsecase := usecase.NewOvertimeUsecase(overtimeRepo, userRepo, payrollRepo, auditRepo)
+	reimbursementUsecase := usecase.NewReimbursementUsecase(reimbursementRepo, userRepo, payrollRepo, auditRepo)
+	payrollUsecase := usecase.NewPayrollUsecase(payrollRepo, userRepo, attendanceRepo, overtimeRepo, reimbursementRepo, holidayRepo, taxRepo, deductionRepo, allowanceRepo, compensationRepo, scheduleRepo, leaveRepo, auditRepo, transactor)
+	companyUsecase := usecase.NewCompanyUsecase(companyRepo, auditRepo)
+	holidayUsecase := usecase.NewHolidayUsecase(holidayRepo, auditRepo, transactor)
+	taxUsecase := usecase.NewTaxUsecase(taxRepo, auditRepo)
+	deductionUsecase := usecase.NewDeductionUsecase(deductionRepo, userRepo, auditRepo)
+	allowanceUsecase := usecase.NewAllowanceUsecase(allowanceRepo, userRepo, auditRepo)
+	compensationUsecase := usecase.NewCompensationUsecase(compensationRepo, userRepo, auditRepo)
+	scheduleUsecase := usecase.NewScheduleUsecase(scheduleRepo, userRepo, auditRepo)
+	leaveUsecase := usecase.NewLeaveUsecase(leaveRepo, userRepo, attendanceRepo, holidayRepo, scheduleRepo, auditRepo, transactor)
+	payGroupUsecase := usecase.NewPayGroupUsecase(payGroupRepo, payrollRepo, auditRepo)
 
 	// Initialize handlers
 	userHandler := handler.NewUserHandler(userUsecase)
@@ -64,9 +83,18 @@ func main() {
 	overtimeHandler := handler.NewOvertimeHandler(overtimeUsecase)
 	reimbursementHandler := handler.NewReimbursementHandler(reimbursementUsecase)
 	payrollHandler := handler.NewPayrollHandler(payrollUsecase)
+	companyHandler := handler.NewCompanyHandler(companyUsecase)
+	holidayHandler := handler.NewHolidayHandler(holidayUsecase)
+	taxHandler := handler.NewTaxHandler(taxUsecase)
+	deductionHandler := handler.NewDeductionHandler(deductionUsecase)
+	allowanceHandler := handler.NewAllowanceHandler(allowanceUsecase)
+	compensationHandler := handler.NewCompensationHandler(compensationUsecase)
+	scheduleHandler := handler.NewScheduleHandler(scheduleUsecase)
+	leaveHandler := handler.NewLeaveHandler(leaveUsecase)
+	payGroupHandler := handler.NewPayGroupHandler(payGroupUsecase)
 
 	// Setup routes
-	router := routes.SetupRoutes(userHandler, attendanceHandler, overtimeHandler, reimbursementHandler, payrollHandler)
+	router := routes.SetupRoutes(userHandler, attendanceHandler, overtimeHandler, reimbursementHandler, payrollHandler, companyHandler, holidayHandler, taxHandler, deductionHandler, allowanceHandler, compensationHandler, scheduleHandler, leaveHandler, payGroupHandler)
 
 	// Start server
 	port := cfg.Port
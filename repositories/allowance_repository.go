@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"payroll/domain/model"
+)
+
+type allowanceRepository struct {
+	db *gorm.DB
+}
+
+func NewAllowanceRepository(db *gorm.DB) AllowanceRepository {
+	return &allowanceRepository{db: db}
+}
+
+func (r *allowanceRepository) Create(allowance *model.Allowance) error {
+	return r.db.Create(allowance).Error
+}
+
+func (r *allowanceRepository) GetByID(id uint) (*model.Allowance, error) {
+	var allowance model.Allowance
+	if err := r.db.Preload("Assignments").First(&allowance, id).Error; err != nil {
+		return nil, err
+	}
+	return &allowance, nil
+}
+
+func (r *allowanceRepository) GetAll() ([]model.Allowance, error) {
+	var allowances []model.Allowance
+	if err := r.db.Preload("Assignments").Order("code").Find(&allowances).Error; err != nil {
+		return nil, err
+	}
+	return allowances, nil
+}
+
+func (r *allowanceRepository) Update(allowance *model.Allowance) error {
+	return r.db.Omit("Assignments").Save(allowance).Error
+}
+
+func (r *allowanceRepository) CreateAssignment(assignment *model.AllowanceAssignment) error {
+	return r.db.Omit("Allowance").Create(assignment).Error
+}
+
+func (r *allowanceRepository) GetAssignmentByID(id uint) (*model.AllowanceAssignment, error) {
+	var assignment model.AllowanceAssignment
+	if err := r.db.Preload("Allowance").First(&assignment, id).Error; err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *allowanceRepository) DeleteAssignment(assignment *model.AllowanceAssignment) error {
+	return r.db.Delete(assignment).Error
+}
+
+// GetAssignmentsForUser returns the assignments of active allowances made to
+// the employee or to their grade.
+func (r *allowanceRepository) GetAssignmentsForUser(userID uint, grade string) ([]model.AllowanceAssignment, error) {
+	var assignments []model.AllowanceAssignment
+	query := r.db.Joins("Allowance").Where(`"Allowance".is_active = ?`, true)
+	if grade != "" {
+		query = query.Where("(allowance_assignments.user_id = ? OR allowance_assignments.grade = ?)", userID, grade)
+	} else {
+		query = query.Where("allowance_assignments.user_id = ?", userID)
+	}
+	if err := query.Order(`"Allowance".code`).Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// GetActiveAssignments returns every assignment of an active allowance, to
+// users and to grades.
+func (r *allowanceRepository) GetActiveAssignments() ([]model.AllowanceAssignment, error) {
+	var assignments []model.AllowanceAssignment
+	if err := r.db.Joins("Allowance").
+		Where(`"Allowance".is_active = ?`, true).
+		Order(`"Allowance".code, allowance_assignments.id`).
+		Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
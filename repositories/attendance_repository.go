@@ -35,6 +35,44 @@ func (r *attendanceRepository) GetByUserAndPeriod(userID uint, startDate, endDat
 	return attendances, nil
 }
 
+// GetPayableByUserAndPeriod returns the records of userID dated startDate to
+// endDate that payroll period payrollPeriodID may pay: the ones no run has
+// taken yet and the ones it took itself.
+func (r *attendanceRepository) GetPayableByUserAndPeriod(userID, payrollPeriodID uint, startDate, endDate time.Time) ([]model.Attendance, error) {
+	var attendances []model.Attendance
+	if err := r.db.Where("user_id = ? AND DATE(date) >= DATE(?) AND DATE(date) <= DATE(?)", userID, startDate, endDate).
+		Where("(payroll_period_id IS NULL AND is_processed = ?) OR payroll_period_id = ?", false, payrollPeriodID).
+		Order("date, id").
+		Find(&attendances).Error; err != nil {
+		return nil, err
+	}
+	return attendances, nil
+}
+
+// GetPayableByPeriod is GetPayableByUserAndPeriod for every employee at
+// once, ordered by employee.
+func (r *attendanceRepository) GetPayableByPeriod(payrollPeriodID uint, startDate, endDate time.Time) ([]model.Attendance, error) {
+	var attendances []model.Attendance
+	if err := r.db.Where("DATE(date) >= DATE(?) AND DATE(date) <= DATE(?)", startDate, endDate).
+		Where("(payroll_period_id IS NULL AND is_processed = ?) OR payroll_period_id = ?", false, payrollPeriodID).
+		Order("user_id, date, id").
+		Find(&attendances).Error; err != nil {
+		return nil, err
+	}
+	return attendances, nil
+}
+
+func (r *attendanceRepository) GetByIDs(ids []uint) ([]model.Attendance, error) {
+	attendances := make([]model.Attendance, 0, len(ids))
+	if len(ids) == 0 {
+		return attendances, nil
+	}
+	if err := r.db.Where("id IN ?", ids).Order("date, id").Find(&attendances).Error; err != nil {
+		return nil, err
+	}
+	return attendances, nil
+}
+
 func (r *attendanceRepository) GetByPeriod(payrollPeriodID uint) ([]model.Attendance, error) {
 	var attendances []model.Attendance
 	if err := r.db.Where("payroll_period_id = ?", payrollPeriodID).Find(&attendances).Error; err != nil {
@@ -47,8 +85,28 @@ func (r *attendanceRepository) Update(attendance *model.Attendance) error {
 	return r.db.Save(attendance).Error
 }
 
+// AssignToPeriod stamps the records with the payroll period that paid them.
+func (r *attendanceRepository) AssignToPeriod(ids []uint, payrollPeriodID uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return inBatches(ids, func(batch []uint) error {
+		return r.db.Model(&model.Attendance{}).
+			Where("id IN ?", batch).
+			Update("payroll_period_id", payrollPeriodID).Error
+	})
+}
+
 func (r *attendanceRepository) MarkAsProcessed(payrollPeriodID uint) error {
 	return r.db.Model(&model.Attendance{}).
 		Where("payroll_period_id = ?", payrollPeriodID).
 		Update("is_processed", true).Error
 }
+
+// UnmarkProcessed releases the records of a reversed run so any period can
+// pay them again.
+func (r *attendanceRepository) UnmarkProcessed(payrollPeriodID uint) error {
+	return r.db.Model(&model.Attendance{}).
+		Where("payroll_period_id = ?", payrollPeriodID).
+		Updates(map[string]interface{}{"is_processed": false, "payroll_period_id": nil}).Error
+}
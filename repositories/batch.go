@@ -0,0 +1,39 @@
+package repositories
+
+import "fmt"
+
+// idBatchSize keeps the IN lists of bulk updates well under PostgreSQL's
+// limit of 65535 bind parameters per statement.
+const idBatchSize = 10000
+
+// payslipBatchSize is how many payslips are inserted per statement. Their
+// items are inserted together, so it is kept small enough for a batch of
+// payslips with many lines to stay under the bind parameter limit.
+const payslipBatchSize = 200
+
+// PayslipBatchError is returned by PayrollRepository.CreatePayslips when a
+// batch of payslips fails to insert. Start and End bound the batch in the
+// payslips passed.
+type PayslipBatchError struct {
+	Start, End int
+	Err        error
+}
+
+func (e *PayslipBatchError) Error() string {
+	return fmt.Sprintf("storing payslips %d to %d: %v", e.Start+1, e.End, e.Err)
+}
+
+func (e *PayslipBatchError) Unwrap() error {
+	return e.Err
+}
+
+// inBatches calls fn with ids split into batches of at most idBatchSize.
+func inBatches(ids []uint, fn func(batch []uint) error) error {
+	for start := 0; start < len(ids); start += idBatchSize {
+		end := min(start+idBatchSize, len(ids))
+		if err := fn(ids[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
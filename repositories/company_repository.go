@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"payroll/domain/model"
+)
+
+type companyRepository struct {
+	db *gorm.DB
+}
+
+func NewCompanyRepository(db *gorm.DB) CompanyRepository {
+	return &companyRepository{db: db}
+}
+
+func (r *companyRepository) Create(company *model.Company) error {
+	return r.db.Create(company).Error
+}
+
+func (r *companyRepository) GetByID(id uint) (*model.Company, error) {
+	var company model.Company
+	if err := r.db.First(&company, id).Error; err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+func (r *companyRepository) GetAll() ([]model.Company, error) {
+	var companies []model.Company
+	if err := r.db.Order("id").Find(&companies).Error; err != nil {
+		return nil, err
+	}
+	return companies, nil
+}
+
+func (r *companyRepository) Update(company *model.Company) error {
+	return r.db.Save(company).Error
+}
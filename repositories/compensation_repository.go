@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"payroll/domain/model"
+)
+
+type compensationRepository struct {
+	db *gorm.DB
+}
+
+func NewCompensationRepository(db *gorm.DB) CompensationRepository {
+	return &compensationRepository{db: db}
+}
+
+func (r *compensationRepository) Create(compensation *model.Compensation) error {
+	return r.db.Create(compensation).Error
+}
+
+func (r *compensationRepository) GetByID(id uint) (*model.Compensation, error) {
+	var compensation model.Compensation
+	if err := r.db.First(&compensation, id).Error; err != nil {
+		return nil, err
+	}
+	return &compensation, nil
+}
+
+// GetByUser returns the salary history of userID ordered by effective date.
+func (r *compensationRepository) GetByUser(userID uint) ([]model.Compensation, error) {
+	var compensations []model.Compensation
+	if err := r.db.Where("user_id = ?", userID).
+		Order("effective_date, id").
+		Find(&compensations).Error; err != nil {
+		return nil, err
+	}
+	return compensations, nil
+}
+
+// GetAll returns the salary history of every user.
+func (r *compensationRepository) GetAll() ([]model.Compensation, error) {
+	var compensations []model.Compensation
+	if err := r.db.Order("user_id, effective_date, id").
+		Find(&compensations).Error; err != nil {
+		return nil, err
+	}
+	return compensations, nil
+}
+
+func (r *compensationRepository) GetByUserAndDate(userID uint, date time.Time) (*model.Compensation, error) {
+	var compensation model.Compensation
+	if err := r.db.Where("user_id = ? AND DATE(effective_date) = DATE(?)", userID, date).First(&compensation).Error; err != nil {
+		return nil, err
+	}
+	return &compensation, nil
+}
+
+func (r *compensationRepository) Delete(compensation *model.Compensation) error {
+	return r.db.Delete(compensation).Error
+}
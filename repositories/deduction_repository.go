@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"payroll/domain/model"
+)
+
+type deductionRepository struct {
+	db *gorm.DB
+}
+
+func NewDeductionRepository(db *gorm.DB) DeductionRepository {
+	return &deductionRepository{db: db}
+}
+
+func (r *deductionRepository) Create(deduction *model.Deduction) error {
+	return r.db.Create(deduction).Error
+}
+
+func (r *deductionRepository) GetByID(id uint) (*model.Deduction, error) {
+	var deduction model.Deduction
+	if err := r.db.Preload("Transactions").First(&deduction, id).Error; err != nil {
+		return nil, err
+	}
+	return &deduction, nil
+}
+
+func (r *deductionRepository) GetByUser(userID uint) ([]model.Deduction, error) {
+	var deductions []model.Deduction
+	if err := r.db.Where("user_id = ?", userID).
+		Preload("Transactions").
+		Order("created_at DESC").
+		Find(&deductions).Error; err != nil {
+		return nil, err
+	}
+	return deductions, nil
+}
+
+// GetActive returns the active deductions of every user that started on or
+// before asOf, oldest first per user, which is the order payroll applies
+// them in.
+func (r *deductionRepository) GetActive(asOf time.Time) ([]model.Deduction, error) {
+	var deductions []model.Deduction
+	if err := r.db.Where("status = ? AND DATE(start_date) <= DATE(?)", model.DeductionActive, asOf).
+		Order("user_id, start_date, id").
+		Find(&deductions).Error; err != nil {
+		return nil, err
+	}
+	return deductions, nil
+}
+
+func (r *deductionRepository) Update(deduction *model.Deduction) error {
+	return r.db.Omit("Transactions", "User").Save(deduction).Error
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"payroll/domain/model"
+)
+
+type holidayRepository struct {
+	db *gorm.DB
+}
+
+func NewHolidayRepository(db *gorm.DB) HolidayRepository {
+	return &holidayRepository{db: db}
+}
+
+func (r *holidayRepository) Create(holiday *model.Holiday) error {
+	return r.db.Create(holiday).Error
+}
+
+func (r *holidayRepository) GetByID(id uint) (*model.Holiday, error) {
+	var holiday model.Holiday
+	if err := r.db.First(&holiday, id).Error; err != nil {
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+func (r *holidayRepository) GetByDate(date time.Time) (*model.Holiday, error) {
+	var holiday model.Holiday
+	if err := r.db.Where("DATE(date) = DATE(?)", date).First(&holiday).Error; err != nil {
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+func (r *holidayRepository) GetByRange(startDate, endDate time.Time) ([]model.Holiday, error) {
+	var holidays []model.Holiday
+	if err := r.db.Where("DATE(date) >= DATE(?) AND DATE(date) <= DATE(?)", startDate, endDate).
+		Order("date").
+		Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+func (r *holidayRepository) Update(holiday *model.Holiday) error {
+	return r.db.Save(holiday).Error
+}
+
+func (r *holidayRepository) Delete(holiday *model.Holiday) error {
+	return r.db.Delete(holiday).Error
+}
@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"payroll/domain/model"
+	"time"
+)
+
+type leaveRepository struct {
+	db *gorm.DB
+}
+
+func NewLeaveRepository(db *gorm.DB) LeaveRepository {
+	return &leaveRepository{db: db}
+}
+
+func (r *leaveRepository) CreateType(leaveType *model.LeaveType) error {
+	return r.db.Create(leaveType).Error
+}
+
+func (r *leaveRepository) GetTypeByID(id uint) (*model.LeaveType, error) {
+	var leaveType model.LeaveType
+	if err := r.db.First(&leaveType, id).Error; err != nil {
+		return nil, err
+	}
+	return &leaveType, nil
+}
+
+func (r *leaveRepository) GetTypes() ([]model.LeaveType, error) {
+	var leaveTypes []model.LeaveType
+	if err := r.db.Order("code").Find(&leaveTypes).Error; err != nil {
+		return nil, err
+	}
+	return leaveTypes, nil
+}
+
+func (r *leaveRepository) UpdateType(leaveType *model.LeaveType) error {
+	return r.db.Save(leaveType).Error
+}
+
+func (r *leaveRepository) Create(request *model.LeaveRequest) error {
+	return r.db.Omit("User", "LeaveType").Create(request).Error
+}
+
+func (r *leaveRepository) GetByID(id uint) (*model.LeaveRequest, error) {
+	var request model.LeaveRequest
+	if err := r.db.Preload("LeaveType").First(&request, id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// LockRequest reads a leave request and locks its row until the
+// transaction ends.
+func (r *leaveRepository) LockRequest(id uint) (*model.LeaveRequest, error) {
+	var request model.LeaveRequest
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: clause.CurrentTable}}).
+		Preload("LeaveType").
+		First(&request, id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// GetRequests lists leave requests, newest first. A zero userID or empty
+// status matches any.
+func (r *leaveRepository) GetRequests(userID uint, status model.LeaveStatus) ([]model.LeaveRequest, error) {
+	query := r.db.Preload("LeaveType")
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var requests []model.LeaveRequest
+	if err := query.Order("start_date DESC, id DESC").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// GetOverlapping returns the pending and approved requests of userID that
+// share at least one day with startDate to endDate.
+func (r *leaveRepository) GetOverlapping(userID uint, startDate, endDate time.Time) ([]model.LeaveRequest, error) {
+	var requests []model.LeaveRequest
+	if err := r.db.Preload("LeaveType").
+		Where("user_id = ? AND status IN ? AND DATE(start_date) <= DATE(?) AND DATE(end_date) >= DATE(?)",
+			userID, []model.LeaveStatus{model.LeavePending, model.LeaveApproved}, endDate, startDate).
+		Order("start_date, id").
+		Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// GetApprovedByUserAndPeriod returns the approved leave of userID that
+// overlaps startDate to endDate.
+func (r *leaveRepository) GetApprovedByUserAndPeriod(userID uint, startDate, endDate time.Time) ([]model.LeaveRequest, error) {
+	var requests []model.LeaveRequest
+	if err := r.db.Preload("LeaveType").
+		Where("user_id = ? AND status = ? AND DATE(start_date) <= DATE(?) AND DATE(end_date) >= DATE(?)",
+			userID, model.LeaveApproved, endDate, startDate).
+		Order("start_date, id").
+		Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// GetApprovedByPeriod is GetApprovedByUserAndPeriod for every employee at
+// once, ordered by employee.
+func (r *leaveRepository) GetApprovedByPeriod(startDate, endDate time.Time) ([]model.LeaveRequest, error) {
+	var requests []model.LeaveRequest
+	if err := r.db.Preload("LeaveType").
+		Where("status = ? AND DATE(start_date) <= DATE(?) AND DATE(end_date) >= DATE(?)",
+			model.LeaveApproved, endDate, startDate).
+		Order("user_id, start_date, id").
+		Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (r *leaveRepository) Update(request *model.LeaveRequest) error {
+	return r.db.Omit("User", "LeaveType").Save(request).Error
+}
+
+func (r *leaveRepository) CreateBalance(balance *model.LeaveBalance) error {
+	return r.db.Omit("LeaveType").Create(balance).Error
+}
+
+func (r *leaveRepository) GetBalance(userID, leaveTypeID uint, year int) (*model.LeaveBalance, error) {
+	var balance model.LeaveBalance
+	if err := r.db.Preload("LeaveType").
+		Where("user_id = ? AND leave_type_id = ? AND year = ?", userID, leaveTypeID, year).
+		First(&balance).Error; err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// LockBalance reads a leave balance and locks its row until the transaction
+// ends.
+func (r *leaveRepository) LockBalance(userID, leaveTypeID uint, year int) (*model.LeaveBalance, error) {
+	var balance model.LeaveBalance
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: clause.CurrentTable}}).
+		Where("user_id = ? AND leave_type_id = ? AND year = ?", userID, leaveTypeID, year).
+		First(&balance).Error; err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+func (r *leaveRepository) GetBalanceByID(id uint) (*model.LeaveBalance, error) {
+	var balance model.LeaveBalance
+	if err := r.db.Preload("LeaveType").First(&balance, id).Error; err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+func (r *leaveRepository) UpdateBalance(balance *model.LeaveBalance) error {
+	return r.db.Omit("LeaveType").Save(balance).Error
+}
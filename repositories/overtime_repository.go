@@ -34,6 +34,44 @@ func (r *overtimeRepository) GetByUserAndPeriod(userID uint, startDate, endDate
 	return overtimes, nil
 }
 
+// GetPayableByUserAndPeriod returns the records of userID dated startDate to
+// endDate that payroll period payrollPeriodID may pay: the ones no run has
+// taken yet and the ones it took itself.
+func (r *overtimeRepository) GetPayableByUserAndPeriod(userID, payrollPeriodID uint, startDate, endDate time.Time) ([]model.Overtime, error) {
+	var overtimes []model.Overtime
+	if err := r.db.Where("user_id = ? AND DATE(date) >= DATE(?) AND DATE(date) <= DATE(?)", userID, startDate, endDate).
+		Where("(payroll_period_id IS NULL AND is_processed = ?) OR payroll_period_id = ?", false, payrollPeriodID).
+		Order("date, id").
+		Find(&overtimes).Error; err != nil {
+		return nil, err
+	}
+	return overtimes, nil
+}
+
+// GetPayableByPeriod is GetPayableByUserAndPeriod for every employee at
+// once, ordered by employee.
+func (r *overtimeRepository) GetPayableByPeriod(payrollPeriodID uint, startDate, endDate time.Time) ([]model.Overtime, error) {
+	var overtimes []model.Overtime
+	if err := r.db.Where("DATE(date) >= DATE(?) AND DATE(date) <= DATE(?)", startDate, endDate).
+		Where("(payroll_period_id IS NULL AND is_processed = ?) OR payroll_period_id = ?", false, payrollPeriodID).
+		Order("user_id, date, id").
+		Find(&overtimes).Error; err != nil {
+		return nil, err
+	}
+	return overtimes, nil
+}
+
+func (r *overtimeRepository) GetByIDs(ids []uint) ([]model.Overtime, error) {
+	overtimes := make([]model.Overtime, 0, len(ids))
+	if len(ids) == 0 {
+		return overtimes, nil
+	}
+	if err := r.db.Where("id IN ?", ids).Order("date, id").Find(&overtimes).Error; err != nil {
+		return nil, err
+	}
+	return overtimes, nil
+}
+
 func (r *overtimeRepository) GetByPeriod(payrollPeriodID uint) ([]model.Overtime, error) {
 	var overtimes []model.Overtime
 	if err := r.db.Where("payroll_period_id = ?", payrollPeriodID).Find(&overtimes).Error; err != nil {
@@ -46,8 +84,28 @@ func (r *overtimeRepository) Update(overtime *model.Overtime) error {
 	return r.db.Save(overtime).Error
 }
 
+// AssignToPeriod stamps the records with the payroll period that paid them.
+func (r *overtimeRepository) AssignToPeriod(ids []uint, payrollPeriodID uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return inBatches(ids, func(batch []uint) error {
+		return r.db.Model(&model.Overtime{}).
+			Where("id IN ?", batch).
+			Update("payroll_period_id", payrollPeriodID).Error
+	})
+}
+
 func (r *overtimeRepository) MarkAsProcessed(payrollPeriodID uint) error {
 	return r.db.Model(&model.Overtime{}).
 		Where("payroll_period_id = ?", payrollPeriodID).
 		Update("is_processed", true).Error
 }
+
+// UnmarkProcessed releases the records of a reversed run so any period can
+// pay them again.
+func (r *overtimeRepository) UnmarkProcessed(payrollPeriodID uint) error {
+	return r.db.Model(&model.Overtime{}).
+		Where("payroll_period_id = ?", payrollPeriodID).
+		Updates(map[string]interface{}{"is_processed": false, "payroll_period_id": nil}).Error
+}
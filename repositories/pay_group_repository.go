@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"payroll/domain/model"
+)
+
+type payGroupRepository struct {
+	db *gorm.DB
+}
+
+func NewPayGroupRepository(db *gorm.DB) PayGroupRepository {
+	return &payGroupRepository{db: db}
+}
+
+func (r *payGroupRepository) Create(group *model.PayGroup) error {
+	return r.db.Create(group).Error
+}
+
+func (r *payGroupRepository) GetByID(id uint) (*model.PayGroup, error) {
+	var group model.PayGroup
+	if err := r.db.First(&group, id).Error; err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *payGroupRepository) GetAll() ([]model.PayGroup, error) {
+	var groups []model.PayGroup
+	if err := r.db.Order("name").Find(&groups).Error; err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (r *payGroupRepository) Update(group *model.PayGroup) error {
+	return r.db.Save(group).Error
+}
@@ -2,7 +2,10 @@ package repositories
 
 import (
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"payroll/domain/model"
+	"sort"
+	"time"
 )
 
 type payrollRepository struct {
@@ -14,17 +17,82 @@ func NewPayrollRepository(db *gorm.DB) PayrollRepository {
 }
 
 func (r *payrollRepository) CreatePeriod(period *model.PayrollPeriod) error {
-	return r.db.Create(period).Error
+	return r.db.Omit("PayGroup").Create(period).Error
 }
 
 func (r *payrollRepository) GetPeriodByID(id uint) (*model.PayrollPeriod, error) {
 	var period model.PayrollPeriod
-	if err := r.db.First(&period, id).Error; err != nil {
+	if err := r.db.Preload("PayGroup").First(&period, id).Error; err != nil {
 		return nil, err
 	}
 	return &period, nil
 }
 
+// LockPeriod loads a period and locks its row until the transaction ends,
+// so two payroll runs of the same period wait for each other.
+func (r *payrollRepository) LockPeriod(id uint) (*model.PayrollPeriod, error) {
+	var period model.PayrollPeriod
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&period, id).Error; err != nil {
+		return nil, err
+	}
+	return &period, nil
+}
+
+// GetPeriods lists periods newest first, filtered by pay group and status
+// when they are given.
+func (r *payrollRepository) GetPeriods(payGroupID uint, status model.PeriodStatus) ([]model.PayrollPeriod, error) {
+	query := r.db.Preload("PayGroup")
+	if payGroupID != 0 {
+		query = query.Where("pay_group_id = ?", payGroupID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var periods []model.PayrollPeriod
+	if err := query.Order("start_date DESC, id DESC").Find(&periods).Error; err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+// GetOverlappingPeriods returns the periods of a pay group that share at
+// least one day with startDate to endDate. A nil payGroupID means the
+// periods without a pay group.
+func (r *payrollRepository) GetOverlappingPeriods(payGroupID *uint, startDate, endDate time.Time) ([]model.PayrollPeriod, error) {
+	query := r.db.Where("DATE(start_date) <= DATE(?) AND DATE(end_date) >= DATE(?)", endDate, startDate)
+	if payGroupID != nil {
+		query = query.Where("pay_group_id = ?", *payGroupID)
+	} else {
+		query = query.Where("pay_group_id IS NULL")
+	}
+
+	var periods []model.PayrollPeriod
+	if err := query.Order("start_date").Find(&periods).Error; err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+// GetProcessedPeriodsAfter returns the periods of a pay group starting after
+// date that payroll has run for. A nil payGroupID means the periods without
+// a pay group.
+func (r *payrollRepository) GetProcessedPeriodsAfter(payGroupID *uint, date time.Time) ([]model.PayrollPeriod, error) {
+	query := r.db.Where("DATE(start_date) > DATE(?) AND status IN ?", date,
+		[]model.PeriodStatus{model.PeriodProcessed, model.PeriodPaid, model.PeriodClosed})
+	if payGroupID != nil {
+		query = query.Where("pay_group_id = ?", *payGroupID)
+	} else {
+		query = query.Where("pay_group_id IS NULL")
+	}
+
+	var periods []model.PayrollPeriod
+	if err := query.Order("start_date").Find(&periods).Error; err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
 func (r *payrollRepository) GetActivePeriods() ([]model.PayrollPeriod, error) {
 	var periods []model.PayrollPeriod
 	if err := r.db.Where("is_processed = ?", false).Find(&periods).Error; err != nil {
@@ -34,18 +102,40 @@ func (r *payrollRepository) GetActivePeriods() ([]model.PayrollPeriod, error) {
 }
 
 func (r *payrollRepository) UpdatePeriod(period *model.PayrollPeriod) error {
-	return r.db.Save(period).Error
+	return r.db.Omit("PayGroup").Save(period).Error
 }
 
+// CreatePayslip stores the payslip with its items, records and deduction
+// transactions; the user and period it belongs to are never written.
 func (r *payrollRepository) CreatePayslip(payslip *model.Payslip) error {
-	return r.db.Create(payslip).Error
+	return r.db.Omit("User", "PayrollPeriod").Create(payslip).Error
+}
+
+// CreatePayslips inserts payslips with their items and records in batches.
+// A batch that fails is returned as a *PayslipBatchError.
+func (r *payrollRepository) CreatePayslips(payslips []model.Payslip) error {
+	for start := 0; start < len(payslips); start += payslipBatchSize {
+		end := min(start+payslipBatchSize, len(payslips))
+		batch := payslips[start:end]
+		if err := r.db.Omit("User", "PayrollPeriod").Create(&batch).Error; err != nil {
+			return &PayslipBatchError{Start: start, End: end, Err: err}
+		}
+	}
+	return nil
+}
+
+func (r *payrollRepository) UpdatePayslip(payslip *model.Payslip) error {
+	return r.db.Omit(clause.Associations).Save(payslip).Error
 }
 
 func (r *payrollRepository) GetPayslipByUserAndPeriod(userID, periodID uint) (*model.Payslip, error) {
 	var payslip model.Payslip
-	if err := r.db.Where("user_id = ? AND payroll_period_id = ?", userID, periodID).
+	if err := r.db.Where("user_id = ? AND payroll_period_id = ? AND status = ? AND kind = ?", userID, periodID, model.PayslipActive, model.PayslipRegular).
 		Preload("User").
 		Preload("PayrollPeriod").
+		Preload("Items").
+		Preload("Records").
+		Preload("SalarySegments").
 		First(&payslip).Error; err != nil {
 		return nil, err
 	}
@@ -54,8 +144,25 @@ func (r *payrollRepository) GetPayslipByUserAndPeriod(userID, periodID uint) (*m
 
 func (r *payrollRepository) GetPayslipsByPeriod(periodID uint) ([]model.Payslip, error) {
 	var payslips []model.Payslip
-	if err := r.db.Where("payroll_period_id = ?", periodID).
+	if err := r.db.Where("payroll_period_id = ? AND status = ?", periodID, model.PayslipActive).
 		Preload("User").
+		Preload("Items").
+		Preload("DeductionTransactions").
+		Find(&payslips).Error; err != nil {
+		return nil, err
+	}
+	return payslips, nil
+}
+
+// GetPayslipsByUserAndPeriod returns the regular payslip of the employee for
+// the period followed by its adjustments, oldest first.
+func (r *payrollRepository) GetPayslipsByUserAndPeriod(userID, periodID uint) ([]model.Payslip, error) {
+	var payslips []model.Payslip
+	if err := r.db.Where("user_id = ? AND payroll_period_id = ? AND status = ?", userID, periodID, model.PayslipActive).
+		Preload("Items").
+		Preload("Records").
+		Preload("SalarySegments").
+		Order("created_at, id").
 		Find(&payslips).Error; err != nil {
 		return nil, err
 	}
@@ -64,11 +171,82 @@ func (r *payrollRepository) GetPayslipsByPeriod(periodID uint) ([]model.Payslip,
 
 func (r *payrollRepository) GetUserPayslips(userID uint) ([]model.Payslip, error) {
 	var payslips []model.Payslip
-	if err := r.db.Where("user_id = ?", userID).
-		Preload("PayrollPeriod").
+	if err := r.db.Where("user_id = ? AND status = ?", userID, model.PayslipActive).
+		Preload("PayrollPeriod.PayGroup").
+		Preload("Items").
+		Preload("Records").
+		Preload("SalarySegments").
 		Order("created_at DESC").
 		Find(&payslips).Error; err != nil {
 		return nil, err
 	}
 	return payslips, nil
 }
+
+// GetUserPayslipsByYear returns the payslips of userID for periods ending in year.
+func (r *payrollRepository) GetUserPayslipsByYear(userID uint, year int) ([]model.Payslip, error) {
+	var payslips []model.Payslip
+	if err := r.db.Joins("PayrollPeriod").
+		Where("payslips.user_id = ? AND payslips.status = ? AND EXTRACT(YEAR FROM \"PayrollPeriod\".end_date) = ?", userID, model.PayslipActive, year).
+		Order("\"PayrollPeriod\".end_date").
+		Find(&payslips).Error; err != nil {
+		return nil, err
+	}
+	return payslips, nil
+}
+
+// GetPayslipsByYear returns the payslips of every user for periods ending in
+// year.
+func (r *payrollRepository) GetPayslipsByYear(year int) ([]model.Payslip, error) {
+	var payslips []model.Payslip
+	if err := r.db.Joins("PayrollPeriod").
+		Where("payslips.status = ? AND EXTRACT(YEAR FROM \"PayrollPeriod\".end_date) = ?", model.PayslipActive, year).
+		Order("payslips.user_id, \"PayrollPeriod\".end_date").
+		Find(&payslips).Error; err != nil {
+		return nil, err
+	}
+	return payslips, nil
+}
+
+// GetRetroPayslips returns the payslips of periods ending before date that
+// retro pay may concern, latest first: those issued before a salary change
+// reaching back into their period was made or cancelled, and those that
+// paid retro pay. They come with their salary segments and their base pay
+// and retro pay lines; their records are left out, GetPayslipRecords loads
+// them for the few payslips that need them. The payslips are read in
+// batches so their preloads stay under the bind parameter limit.
+func (r *payrollRepository) GetRetroPayslips(date time.Time) ([]model.Payslip, error) {
+	var payslips, batch []model.Payslip
+	periods := r.db.Model(&model.PayrollPeriod{}).Select("id").Where("DATE(end_date) < DATE(?)", date)
+	err := r.db.Where("status = ? AND payroll_period_id IN (?)", model.PayslipActive, periods).
+		Where(`EXISTS (SELECT 1 FROM compensations c JOIN payroll_periods pp ON pp.id = payslips.payroll_period_id
+			WHERE c.user_id = payslips.user_id AND DATE(c.effective_date) <= DATE(pp.end_date)
+			AND (c.created_at > payslips.created_at OR c.deleted_at > payslips.created_at))
+			OR EXISTS (SELECT 1 FROM payslip_items i WHERE i.payslip_id = payslips.id AND i.code = ? AND i.deleted_at IS NULL)`,
+			model.PayCodeRetro).
+		Preload("PayrollPeriod.PayGroup").
+		Preload("Items", "code IN ?", []string{model.PayCodeBasePay, model.PayCodeRetro}).
+		Preload("SalarySegments").
+		FindInBatches(&batch, idBatchSize, func(tx *gorm.DB, _ int) error {
+			payslips = append(payslips, batch...)
+			return nil
+		}).Error
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(payslips, func(i, j int) bool {
+		return payslips[i].CreatedAt.After(payslips[j].CreatedAt)
+	})
+	return payslips, nil
+}
+
+// GetPayslipRecords returns the records payslipID paid.
+func (r *payrollRepository) GetPayslipRecords(payslipID uint) ([]model.PayslipRecord, error) {
+	var records []model.PayslipRecord
+	if err := r.db.Where("payslip_id = ?", payslipID).
+		Order("id").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -20,7 +20,45 @@ func (r *reimbursementRepository) Create(reimbursement *model.Reimbursement) err
 
 func (r *reimbursementRepository) GetByUserAndPeriod(userID uint, startDate, endDate time.Time) ([]model.Reimbursement, error) {
 	var reimbursements []model.Reimbursement
-	if err := r.db.Where("user_id = ? AND created_at >= ? AND created_at <= ?", userID, startDate, endDate).Find(&reimbursements).Error; err != nil {
+	if err := r.db.Where("user_id = ? AND DATE(date) >= DATE(?) AND DATE(date) <= DATE(?)", userID, startDate, endDate).Find(&reimbursements).Error; err != nil {
+		return nil, err
+	}
+	return reimbursements, nil
+}
+
+// GetPayableByUserAndPeriod returns the records of userID dated startDate to
+// endDate that payroll period payrollPeriodID may pay: the ones no run has
+// taken yet and the ones it took itself.
+func (r *reimbursementRepository) GetPayableByUserAndPeriod(userID, payrollPeriodID uint, startDate, endDate time.Time) ([]model.Reimbursement, error) {
+	var reimbursements []model.Reimbursement
+	if err := r.db.Where("user_id = ? AND DATE(date) >= DATE(?) AND DATE(date) <= DATE(?)", userID, startDate, endDate).
+		Where("(payroll_period_id IS NULL AND is_processed = ?) OR payroll_period_id = ?", false, payrollPeriodID).
+		Order("date, id").
+		Find(&reimbursements).Error; err != nil {
+		return nil, err
+	}
+	return reimbursements, nil
+}
+
+// GetPayableByPeriod is GetPayableByUserAndPeriod for every employee at
+// once, ordered by employee.
+func (r *reimbursementRepository) GetPayableByPeriod(payrollPeriodID uint, startDate, endDate time.Time) ([]model.Reimbursement, error) {
+	var reimbursements []model.Reimbursement
+	if err := r.db.Where("DATE(date) >= DATE(?) AND DATE(date) <= DATE(?)", startDate, endDate).
+		Where("(payroll_period_id IS NULL AND is_processed = ?) OR payroll_period_id = ?", false, payrollPeriodID).
+		Order("user_id, date, id").
+		Find(&reimbursements).Error; err != nil {
+		return nil, err
+	}
+	return reimbursements, nil
+}
+
+func (r *reimbursementRepository) GetByIDs(ids []uint) ([]model.Reimbursement, error) {
+	reimbursements := make([]model.Reimbursement, 0, len(ids))
+	if len(ids) == 0 {
+		return reimbursements, nil
+	}
+	if err := r.db.Where("id IN ?", ids).Order("date, id").Find(&reimbursements).Error; err != nil {
 		return nil, err
 	}
 	return reimbursements, nil
@@ -38,8 +76,28 @@ func (r *reimbursementRepository) Update(reimbursement *model.Reimbursement) err
 	return r.db.Save(reimbursement).Error
 }
 
+// AssignToPeriod stamps the records with the payroll period that paid them.
+func (r *reimbursementRepository) AssignToPeriod(ids []uint, payrollPeriodID uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return inBatches(ids, func(batch []uint) error {
+		return r.db.Model(&model.Reimbursement{}).
+			Where("id IN ?", batch).
+			Update("payroll_period_id", payrollPeriodID).Error
+	})
+}
+
 func (r *reimbursementRepository) MarkAsProcessed(payrollPeriodID uint) error {
 	return r.db.Model(&model.Reimbursement{}).
 		Where("payroll_period_id = ?", payrollPeriodID).
 		Update("is_processed", true).Error
 }
+
+// UnmarkProcessed releases the records of a reversed run so any period can
+// pay them again.
+func (r *reimbursementRepository) UnmarkProcessed(payrollPeriodID uint) error {
+	return r.db.Model(&model.Reimbursement{}).
+		Where("payroll_period_id = ?", payrollPeriodID).
+		Updates(map[string]interface{}{"is_processed": false, "payroll_period_id": nil}).Error
+}
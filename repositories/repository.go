@@ -17,37 +17,153 @@ type AttendanceRepository interface {
 	Create(attendance *model.Attendance) error
 	GetByUserAndDate(userID uint, date time.Time) (*model.Attendance, error)
 	GetByUserAndPeriod(userID uint, startDate, endDate time.Time) ([]model.Attendance, error)
+	GetPayableByUserAndPeriod(userID, payrollPeriodID uint, startDate, endDate time.Time) ([]model.Attendance, error)
+	GetPayableByPeriod(payrollPeriodID uint, startDate, endDate time.Time) ([]model.Attendance, error)
+	GetByIDs(ids []uint) ([]model.Attendance, error)
 	GetByPeriod(payrollPeriodID uint) ([]model.Attendance, error)
 	Update(attendance *model.Attendance) error
+	AssignToPeriod(ids []uint, payrollPeriodID uint) error
 	MarkAsProcessed(payrollPeriodID uint) error
+	UnmarkProcessed(payrollPeriodID uint) error
 }
 
 type OvertimeRepository interface {
 	Create(overtime *model.Overtime) error
 	GetByUserAndDate(userID uint, date time.Time) (*model.Overtime, error)
 	GetByUserAndPeriod(userID uint, startDate, endDate time.Time) ([]model.Overtime, error)
+	GetPayableByUserAndPeriod(userID, payrollPeriodID uint, startDate, endDate time.Time) ([]model.Overtime, error)
+	GetPayableByPeriod(payrollPeriodID uint, startDate, endDate time.Time) ([]model.Overtime, error)
+	GetByIDs(ids []uint) ([]model.Overtime, error)
 	GetByPeriod(payrollPeriodID uint) ([]model.Overtime, error)
 	Update(overtime *model.Overtime) error
+	AssignToPeriod(ids []uint, payrollPeriodID uint) error
 	MarkAsProcessed(payrollPeriodID uint) error
+	UnmarkProcessed(payrollPeriodID uint) error
 }
 
 type ReimbursementRepository interface {
 	Create(reimbursement *model.Reimbursement) error
 	GetByUserAndPeriod(userID uint, startDate, endDate time.Time) ([]model.Reimbursement, error)
+	GetPayableByUserAndPeriod(userID, payrollPeriodID uint, startDate, endDate time.Time) ([]model.Reimbursement, error)
+	GetPayableByPeriod(payrollPeriodID uint, startDate, endDate time.Time) ([]model.Reimbursement, error)
+	GetByIDs(ids []uint) ([]model.Reimbursement, error)
 	GetByPeriod(payrollPeriodID uint) ([]model.Reimbursement, error)
 	Update(reimbursement *model.Reimbursement) error
+	AssignToPeriod(ids []uint, payrollPeriodID uint) error
 	MarkAsProcessed(payrollPeriodID uint) error
+	UnmarkProcessed(payrollPeriodID uint) error
 }
 
 type PayrollRepository interface {
 	CreatePeriod(period *model.PayrollPeriod) error
 	GetPeriodByID(id uint) (*model.PayrollPeriod, error)
+	LockPeriod(id uint) (*model.PayrollPeriod, error)
+	GetPeriods(payGroupID uint, status model.PeriodStatus) ([]model.PayrollPeriod, error)
+	GetOverlappingPeriods(payGroupID *uint, startDate, endDate time.Time) ([]model.PayrollPeriod, error)
+	GetProcessedPeriodsAfter(payGroupID *uint, date time.Time) ([]model.PayrollPeriod, error)
 	GetActivePeriods() ([]model.PayrollPeriod, error)
 	UpdatePeriod(period *model.PayrollPeriod) error
 	CreatePayslip(payslip *model.Payslip) error
+	CreatePayslips(payslips []model.Payslip) error
+	UpdatePayslip(payslip *model.Payslip) error
 	GetPayslipByUserAndPeriod(userID, periodID uint) (*model.Payslip, error)
 	GetPayslipsByPeriod(periodID uint) ([]model.Payslip, error)
+	GetPayslipsByUserAndPeriod(userID, periodID uint) ([]model.Payslip, error)
 	GetUserPayslips(userID uint) ([]model.Payslip, error)
+	GetUserPayslipsByYear(userID uint, year int) ([]model.Payslip, error)
+	GetPayslipsByYear(year int) ([]model.Payslip, error)
+	GetRetroPayslips(date time.Time) ([]model.Payslip, error)
+	GetPayslipRecords(payslipID uint) ([]model.PayslipRecord, error)
+}
+
+type PayGroupRepository interface {
+	Create(group *model.PayGroup) error
+	GetByID(id uint) (*model.PayGroup, error)
+	GetAll() ([]model.PayGroup, error)
+	Update(group *model.PayGroup) error
+}
+
+type CompanyRepository interface {
+	Create(company *model.Company) error
+	GetByID(id uint) (*model.Company, error)
+	GetAll() ([]model.Company, error)
+	Update(company *model.Company) error
+}
+
+type HolidayRepository interface {
+	Create(holiday *model.Holiday) error
+	GetByID(id uint) (*model.Holiday, error)
+	GetByDate(date time.Time) (*model.Holiday, error)
+	GetByRange(startDate, endDate time.Time) ([]model.Holiday, error)
+	Update(holiday *model.Holiday) error
+	Delete(holiday *model.Holiday) error
+}
+
+type TaxRepository interface {
+	GetTable(year int) (*model.TaxTable, error)
+	ReplaceTable(table *model.TaxTable) error
+}
+
+type ScheduleRepository interface {
+	CreateShift(shift *model.Shift) error
+	GetShiftByID(id uint) (*model.Shift, error)
+	GetShifts() ([]model.Shift, error)
+	UpdateShift(shift *model.Shift) error
+	CreateSchedule(schedule *model.WorkSchedule) error
+	GetScheduleByID(id uint) (*model.WorkSchedule, error)
+	GetSchedules() ([]model.WorkSchedule, error)
+	CreateAssignment(assignment *model.ScheduleAssignment) error
+	GetAssignmentsByUser(userID uint) ([]model.ScheduleAssignment, error)
+	GetAssignments() ([]model.ScheduleAssignment, error)
+}
+
+type LeaveRepository interface {
+	CreateType(leaveType *model.LeaveType) error
+	GetTypeByID(id uint) (*model.LeaveType, error)
+	GetTypes() ([]model.LeaveType, error)
+	UpdateType(leaveType *model.LeaveType) error
+	Create(request *model.LeaveRequest) error
+	GetByID(id uint) (*model.LeaveRequest, error)
+	LockRequest(id uint) (*model.LeaveRequest, error)
+	GetRequests(userID uint, status model.LeaveStatus) ([]model.LeaveRequest, error)
+	GetOverlapping(userID uint, startDate, endDate time.Time) ([]model.LeaveRequest, error)
+	GetApprovedByUserAndPeriod(userID uint, startDate, endDate time.Time) ([]model.LeaveRequest, error)
+	GetApprovedByPeriod(startDate, endDate time.Time) ([]model.LeaveRequest, error)
+	Update(request *model.LeaveRequest) error
+	CreateBalance(balance *model.LeaveBalance) error
+	GetBalance(userID, leaveTypeID uint, year int) (*model.LeaveBalance, error)
+	LockBalance(userID, leaveTypeID uint, year int) (*model.LeaveBalance, error)
+	GetBalanceByID(id uint) (*model.LeaveBalance, error)
+	UpdateBalance(balance *model.LeaveBalance) error
+}
+
+type CompensationRepository interface {
+	Create(compensation *model.Compensation) error
+	GetByID(id uint) (*model.Compensation, error)
+	GetByUser(userID uint) ([]model.Compensation, error)
+	GetAll() ([]model.Compensation, error)
+	GetByUserAndDate(userID uint, date time.Time) (*model.Compensation, error)
+	Delete(compensation *model.Compensation) error
+}
+
+type AllowanceRepository interface {
+	Create(allowance *model.Allowance) error
+	GetByID(id uint) (*model.Allowance, error)
+	GetAll() ([]model.Allowance, error)
+	Update(allowance *model.Allowance) error
+	CreateAssignment(assignment *model.AllowanceAssignment) error
+	GetAssignmentByID(id uint) (*model.AllowanceAssignment, error)
+	DeleteAssignment(assignment *model.AllowanceAssignment) error
+	GetAssignmentsForUser(userID uint, grade string) ([]model.AllowanceAssignment, error)
+	GetActiveAssignments() ([]model.AllowanceAssignment, error)
+}
+
+type DeductionRepository interface {
+	Create(deduction *model.Deduction) error
+	GetByID(id uint) (*model.Deduction, error)
+	GetByUser(userID uint) ([]model.Deduction, error)
+	GetActive(asOf time.Time) ([]model.Deduction, error)
+	Update(deduction *model.Deduction) error
 }
 
 type AuditRepository interface {
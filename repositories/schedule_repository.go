@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"payroll/domain/model"
+)
+
+type scheduleRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduleRepository(db *gorm.DB) ScheduleRepository {
+	return &scheduleRepository{db: db}
+}
+
+func (r *scheduleRepository) CreateShift(shift *model.Shift) error {
+	return r.db.Create(shift).Error
+}
+
+func (r *scheduleRepository) GetShiftByID(id uint) (*model.Shift, error) {
+	var shift model.Shift
+	if err := r.db.First(&shift, id).Error; err != nil {
+		return nil, err
+	}
+	return &shift, nil
+}
+
+func (r *scheduleRepository) GetShifts() ([]model.Shift, error) {
+	var shifts []model.Shift
+	if err := r.db.Order("code").Find(&shifts).Error; err != nil {
+		return nil, err
+	}
+	return shifts, nil
+}
+
+func (r *scheduleRepository) UpdateShift(shift *model.Shift) error {
+	return r.db.Save(shift).Error
+}
+
+// CreateSchedule stores the schedule with its days; the shifts they refer to
+// must already exist.
+func (r *scheduleRepository) CreateSchedule(schedule *model.WorkSchedule) error {
+	return r.db.Omit("Days.Shift").Create(schedule).Error
+}
+
+func (r *scheduleRepository) GetScheduleByID(id uint) (*model.WorkSchedule, error) {
+	var schedule model.WorkSchedule
+	if err := r.db.Preload("Days.Shift").First(&schedule, id).Error; err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *scheduleRepository) GetSchedules() ([]model.WorkSchedule, error) {
+	var schedules []model.WorkSchedule
+	if err := r.db.Preload("Days.Shift").Order("name").Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (r *scheduleRepository) CreateAssignment(assignment *model.ScheduleAssignment) error {
+	return r.db.Omit("WorkSchedule").Create(assignment).Error
+}
+
+// GetAssignmentsByUser returns the schedule assignments of userID ordered by
+// effective date, with their schedules and shifts.
+func (r *scheduleRepository) GetAssignmentsByUser(userID uint) ([]model.ScheduleAssignment, error) {
+	var assignments []model.ScheduleAssignment
+	if err := r.db.Where("user_id = ?", userID).
+		Preload("WorkSchedule.Days.Shift").
+		Order("effective_date, id").
+		Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// GetAssignments returns the schedule assignments of every user.
+func (r *scheduleRepository) GetAssignments() ([]model.ScheduleAssignment, error) {
+	var assignments []model.ScheduleAssignment
+	if err := r.db.Preload("WorkSchedule.Days.Shift").
+		Order("user_id, effective_date, id").
+		Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
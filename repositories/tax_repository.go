@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"payroll/domain/model"
+)
+
+type taxRepository struct {
+	db *gorm.DB
+}
+
+func NewTaxRepository(db *gorm.DB) TaxRepository {
+	return &taxRepository{db: db}
+}
+
+// GetTable returns the newest tax table that is in force for year.
+func (r *taxRepository) GetTable(year int) (*model.TaxTable, error) {
+	var ptkp model.TaxPTKP
+	if err := r.db.Where("effective_year <= ?", year).
+		Order("effective_year DESC").
+		First(&ptkp).Error; err != nil {
+		return nil, err
+	}
+
+	table := &model.TaxTable{EffectiveYear: ptkp.EffectiveYear}
+	if err := r.db.Where("effective_year = ?", table.EffectiveYear).
+		Order("status").
+		Find(&table.PTKP).Error; err != nil {
+		return nil, err
+	}
+	// Rows with no upper limit (0) sort last within their category.
+	if err := r.db.Where("effective_year = ?", table.EffectiveYear).
+		Order("category").
+		Order("max_income = 0").
+		Order("max_income").
+		Find(&table.TERRates).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("effective_year = ?", table.EffectiveYear).
+		Order("max_income = 0").
+		Order("max_income").
+		Find(&table.Brackets).Error; err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// ReplaceTable swaps every row of table.EffectiveYear for the given ones.
+func (r *taxRepository) ReplaceTable(table *model.TaxTable) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, rows := range []interface{}{&model.TaxPTKP{}, &model.TaxTERRate{}, &model.TaxBracket{}} {
+			if err := tx.Unscoped().Where("effective_year = ?", table.EffectiveYear).Delete(rows).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range table.PTKP {
+			table.PTKP[i].EffectiveYear = table.EffectiveYear
+		}
+		for i := range table.TERRates {
+			table.TERRates[i].EffectiveYear = table.EffectiveYear
+		}
+		for i := range table.Brackets {
+			table.Brackets[i].EffectiveYear = table.EffectiveYear
+		}
+
+		if err := tx.Create(&table.PTKP).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&table.TERRates).Error; err != nil {
+			return err
+		}
+		return tx.Create(&table.Brackets).Error
+	})
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import "gorm.io/gorm"
+
+// Repositories bundles every repository over one database handle, so work
+// done through them can share a transaction.
+type Repositories struct {
+	User          UserRepository
+	Attendance    AttendanceRepository
+	Overtime      OvertimeRepository
+	Reimbursement ReimbursementRepository
+	Payroll       PayrollRepository
+	PayGroup      PayGroupRepository
+	Company       CompanyRepository
+	Holiday       HolidayRepository
+	Tax           TaxRepository
+	Deduction     DeductionRepository
+	Allowance     AllowanceRepository
+	Compensation  CompensationRepository
+	Schedule      ScheduleRepository
+	Leave         LeaveRepository
+	Audit         AuditRepository
+}
+
+func NewRepositories(db *gorm.DB) *Repositories {
+	return &Repositories{
+		User:          NewUserRepository(db),
+		Attendance:    NewAttendanceRepository(db),
+		Overtime:      NewOvertimeRepository(db),
+		Reimbursement: NewReimbursementRepository(db),
+		Payroll:       NewPayrollRepository(db),
+		PayGroup:      NewPayGroupRepository(db),
+		Company:       NewCompanyRepository(db),
+		Holiday:       NewHolidayRepository(db),
+		Tax:           NewTaxRepository(db),
+		Deduction:     NewDeductionRepository(db),
+		Allowance:     NewAllowanceRepository(db),
+		Compensation:  NewCompensationRepository(db),
+		Schedule:      NewScheduleRepository(db),
+		Leave:         NewLeaveRepository(db),
+		Audit:         NewAuditRepository(db),
+	}
+}
+
+// Transactor runs work in a database transaction.
+type Transactor interface {
+	// WithinTransaction calls fn with repositories bound to a new
+	// transaction, committing it when fn returns nil and rolling it back
+	// when fn returns an error or panics.
+	WithinTransaction(fn func(repos *Repositories) error) error
+}
+
+type transactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) Transactor {
+	return &transactor{db: db}
+}
+
+func (t *transactor) WithinTransaction(fn func(repos *Repositories) error) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx))
+	})
+}
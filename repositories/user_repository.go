@@ -23,7 +23,7 @@ func (r *userRepository) GetByUsername(username string) (*model.User, error) {
 
 func (r *userRepository) GetByID(id uint) (*model.User, error) {
 	var user model.User
-	if err := r.db.First(&user, id).Error; err != nil {
+	if err := r.db.Preload("Company").First(&user, id).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
@@ -39,7 +39,7 @@ func (r *userRepository) Update(user *model.User) error {
 
 func (r *userRepository) GetAll() ([]model.User, error) {
 	var users []model.User
-	if err := r.db.Find(&users).Error; err != nil {
+	if err := r.db.Preload("Company").Find(&users).Error; err != nil {
 		return nil, err
 	}
 	return users, nil
@@ -12,6 +12,15 @@ func SetupRoutes(
 	overtimeHandler *handler.OvertimeHandler,
 	reimbursementHandler *handler.ReimbursementHandler,
 	payrollHandler *handler.PayrollHandler,
+	companyHandler *handler.CompanyHandler,
+	holidayHandler *handler.HolidayHandler,
+	taxHandler *handler.TaxHandler,
+	deductionHandler *handler.DeductionHandler,
+	allowanceHandler *handler.AllowanceHandler,
+	compensationHandler *handler.CompensationHandler,
+	scheduleHandler *handler.ScheduleHandler,
+	leaveHandler *handler.LeaveHandler,
+	payGroupHandler *handler.PayGroupHandler,
 ) *gin.Engine {
 	router := gin.Default()
 
@@ -40,9 +49,67 @@ func SetupRoutes(
 		admin := api.Group("/admin")
 		admin.Use(utils.AdminMiddleware())
 		{
+			admin.GET("/payroll-periods", payrollHandler.GetPayrollPeriods)
 			admin.POST("/payroll-periods", payrollHandler.CreatePayrollPeriod)
+			admin.POST("/payroll-periods/:id/transition", payrollHandler.TransitionPayrollPeriod)
 			admin.POST("/payroll/run", payrollHandler.RunPayroll)
+			admin.POST("/payroll/preview", payrollHandler.PreviewPayroll)
+			admin.POST("/payroll/reverse", payrollHandler.ReversePayroll)
+			admin.POST("/payroll/corrections", payrollHandler.CorrectPayroll)
+			admin.POST("/payroll/mark-paid", payrollHandler.MarkPayrollPaid)
 			admin.GET("/payroll/summary", payrollHandler.GetPayrollSummary)
+
+			admin.GET("/companies", companyHandler.GetCompanies)
+			admin.POST("/companies", companyHandler.CreateCompany)
+			admin.PUT("/companies/:id", companyHandler.UpdateCompany)
+			admin.GET("/pay-groups", payGroupHandler.GetPayGroups)
+			admin.POST("/pay-groups", payGroupHandler.CreatePayGroup)
+			admin.PUT("/pay-groups/:id", payGroupHandler.UpdatePayGroup)
+			admin.POST("/pay-groups/:id/periods", payGroupHandler.GeneratePeriods)
+			admin.PUT("/employees/:id", userHandler.UpdateEmployee)
+			admin.POST("/employees/:id/attendance", attendanceHandler.RecordAttendance)
+			admin.POST("/employees/:id/overtime", overtimeHandler.RecordOvertime)
+			admin.POST("/employees/:id/reimbursement", reimbursementHandler.RecordReimbursement)
+
+			admin.GET("/holidays", holidayHandler.GetHolidays)
+			admin.POST("/holidays", holidayHandler.CreateHoliday)
+			admin.POST("/holidays/import", holidayHandler.ImportHolidays)
+			admin.PUT("/holidays/:id", holidayHandler.UpdateHoliday)
+			admin.DELETE("/holidays/:id", holidayHandler.DeleteHoliday)
+
+			admin.GET("/tax-tables", taxHandler.GetTaxTable)
+			admin.PUT("/tax-tables", taxHandler.ReplaceTaxTable)
+
+			admin.GET("/shifts", scheduleHandler.GetShifts)
+			admin.POST("/shifts", scheduleHandler.CreateShift)
+			admin.PUT("/shifts/:id", scheduleHandler.UpdateShift)
+			admin.GET("/work-schedules", scheduleHandler.GetSchedules)
+			admin.POST("/work-schedules", scheduleHandler.CreateSchedule)
+			admin.GET("/schedule-assignments", scheduleHandler.GetAssignments)
+			admin.POST("/schedule-assignments", scheduleHandler.AssignSchedule)
+
+			admin.GET("/leave-types", leaveHandler.GetLeaveTypes)
+			admin.POST("/leave-types", leaveHandler.CreateLeaveType)
+			admin.PUT("/leave-types/:id", leaveHandler.UpdateLeaveType)
+			admin.GET("/leave-requests", leaveHandler.GetLeaveRequests)
+			admin.POST("/leave-requests/:id/approve", leaveHandler.ApproveLeave)
+			admin.POST("/leave-requests/:id/reject", leaveHandler.RejectLeave)
+			admin.GET("/leave-balances", leaveHandler.GetLeaveBalances)
+			admin.POST("/leave-balances/accrue", leaveHandler.AccrueLeave)
+
+			admin.GET("/compensations", compensationHandler.GetCompensationHistory)
+			admin.POST("/compensations", compensationHandler.CreateCompensation)
+			admin.DELETE("/compensations/:id", compensationHandler.DeleteCompensation)
+
+			admin.GET("/allowances", allowanceHandler.GetAllowances)
+			admin.POST("/allowances", allowanceHandler.CreateAllowance)
+			admin.PUT("/allowances/:id", allowanceHandler.UpdateAllowance)
+			admin.POST("/allowances/:id/assignments", allowanceHandler.AssignAllowance)
+			admin.DELETE("/allowance-assignments/:id", allowanceHandler.RemoveAssignment)
+
+			admin.GET("/deductions", deductionHandler.GetDeductions)
+			admin.POST("/deductions", deductionHandler.CreateDeduction)
+			admin.POST("/deductions/:id/cancel", deductionHandler.CancelDeduction)
 		}
 
 		// Employee routes
@@ -52,6 +119,10 @@ func SetupRoutes(
 			employee.POST("/attendance", attendanceHandler.SubmitAttendance)
 			employee.POST("/overtime", overtimeHandler.SubmitOvertime)
 			employee.POST("/reimbursement", reimbursementHandler.SubmitReimbursement)
+			employee.GET("/leave", leaveHandler.GetMyLeaveRequests)
+			employee.POST("/leave", leaveHandler.SubmitLeave)
+			employee.POST("/leave/:id/cancel", leaveHandler.CancelLeave)
+			employee.GET("/leave-balances", leaveHandler.GetMyLeaveBalances)
 			employee.GET("/payslip", payrollHandler.GeneratePayslip)
 		}
 	}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/testcontainers/testcontainers-go/wait"
 	"golang.org/x/crypto/bcrypt"
@@ -15,6 +16,7 @@ import (
 	"payroll/routes"
 	"payroll/utils"
 	"runtime"
+	"sync"
 	"testing"
 	"time"
 
@@ -27,6 +29,7 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"payroll/database"
 	"payroll/delivery/http/handler"
 	"payroll/domain/model"
 	"payroll/repositories"
@@ -42,9 +45,36 @@ type TestSuite struct {
 	employeeToken string
 	adminUser     *model.User
 	employeeUser  *model.User
+	failing       *failingComponent
+}
+
+// failingComponent fails the payslip of the employee it is set to, so tests
+// can see how a payroll run handles employees that cannot be paid.
+type failingComponent struct {
+	userID uint
+}
+
+func (f *failingComponent) Code() string { return "TEST_FAILURE" }
+
+func (f *failingComponent) Calculate(pc *usecase.PayContext) ([]model.PayslipItem, error) {
+	if f.userID != 0 && pc.User.ID == f.userID {
+		return nil, errors.New("test failure")
+	}
+	return nil, nil
 }
 
 func (s *TestSuite) SetupSuite() {
+	s.container = startPostgres(s.T())
+	s.db = connectPostgres(s.T(), s.container)
+
+	// 5. Run migrations
+	s.runMigrations()
+	s.setupTestData()
+	s.setupRouter()
+}
+
+// startPostgres starts a PostgreSQL container for a test or benchmark.
+func startPostgres(tb testing.TB) *pg.PostgresContainer {
 	if runtime.GOOS == "darwin" {
 		os.Setenv("TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE", "/var/run/docker.sock")
 		os.Setenv("DOCKER_HOST", "unix:///var/run/docker.sock")
@@ -62,12 +92,17 @@ func (s *TestSuite) SetupSuite() {
 				WithOccurrence(2).
 				WithStartupTimeout(30*time.Second)),
 	)
-	require.NoError(s.T(), err)
-	s.container = pgContainer
+	require.NoError(tb, err)
+	return pgContainer
+}
+
+// connectPostgres connects to the database in pgContainer.
+func connectPostgres(tb testing.TB, pgContainer *pg.PostgresContainer) *gorm.DB {
+	ctx := context.Background()
 
 	// 2. Get mapped port
 	port, err := pgContainer.MappedPort(ctx, "5432")
-	require.NoError(s.T(), err, "Failed to get mapped port")
+	require.NoError(tb, err, "Failed to get mapped port")
 
 	// 3. Build connection string
 	connStr := fmt.Sprintf("host=localhost port=%s user=tests dbname=payroll_test password=tests sslmode=disable connect_timeout=5", port.Port())
@@ -83,21 +118,16 @@ func (s *TestSuite) SetupSuite() {
 				err = sqlDB.Ping()
 			}
 			if err == nil {
-				s.db = db
 				break
 			}
 		}
 
 		if i == maxAttempts-1 {
-			require.NoError(s.T(), err, "Failed to connect after %d attempts", maxAttempts)
+			require.NoError(tb, err, "Failed to connect after %d attempts", maxAttempts)
 		}
 		time.Sleep(2 * time.Second)
 	}
-
-	// 5. Run migrations
-	s.runMigrations()
-	s.setupTestData()
-	s.setupRouter()
+	return db
 }
 
 func (s *TestSuite) TearDownSuite() {
@@ -117,13 +147,34 @@ func (s *TestSuite) SetupTest() {
 
 func (s *TestSuite) runMigrations() {
 	models := []interface{}{
+		&model.Company{},
+		&model.PayGroup{},
 		&model.User{},
 		&model.Attendance{},
 		&model.Overtime{},
 		&model.Reimbursement{},
 		&model.PayrollPeriod{},
 		&model.Payslip{},
+		&model.PayslipItem{},
+		&model.PayslipRecord{},
+		&model.PayslipSalarySegment{},
 		&model.AuditLog{},
+		&model.Holiday{},
+		&model.TaxPTKP{},
+		&model.TaxTERRate{},
+		&model.TaxBracket{},
+		&model.Deduction{},
+		&model.DeductionTransaction{},
+		&model.Allowance{},
+		&model.AllowanceAssignment{},
+		&model.Compensation{},
+		&model.Shift{},
+		&model.WorkSchedule{},
+		&model.WorkScheduleDay{},
+		&model.ScheduleAssignment{},
+		&model.LeaveType{},
+		&model.LeaveRequest{},
+		&model.LeaveBalance{},
 	}
 
 	for _, model := range models {
@@ -133,6 +184,8 @@ func (s *TestSuite) runMigrations() {
 }
 
 func (s *TestSuite) setupTestData() {
+	require.NoError(s.T(), database.SeedTaxTables(s.db), "Failed to seed tax tables")
+
 	// Create admin user
 	password, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
 	adminUser := &model.User{
@@ -171,16 +224,38 @@ func (s *TestSuite) setupRouter() {
 	reimbursementRepo := repositories.NewReimbursementRepository(s.db)
 	payrollRepo := repositories.NewPayrollRepository(s.db)
 	auditRepo := repositories.NewAuditRepository(s.db)
+	companyRepo := repositories.NewCompanyRepository(s.db)
+	holidayRepo := repositories.NewHolidayRepository(s.db)
+	taxRepo := repositories.NewTaxRepository(s.db)
+	deductionRepo := repositories.NewDeductionRepository(s.db)
+	allowanceRepo := repositories.NewAllowanceRepository(s.db)
+	compensationRepo := repositories.NewCompensationRepository(s.db)
+	scheduleRepo := repositories.NewScheduleRepository(s.db)
+	leaveRepo := repositories.NewLeaveRepository(s.db)
+	payGroupRepo := repositories.NewPayGroupRepository(s.db)
+	transactor := repositories.NewTransactor(s.db)
 
 	// Initialize use cases
 	userUsecase := usecase.NewUserUsecase(userRepo, auditRepo)
-	attendanceUsecase := usecase.NewAttendanceUsecase(attendanceRepo, auditRepo)
-	overtimeUsecase := usecase.NewOvertimeUsecase(overtimeRepo, auditRepo)
-	reimbursementUsecase := usecase.NewReimbursementUsecase(reimbursementRepo, auditRepo)
+	attendanceUsecase := usecase.NewAttendanceUsecase(attendanceRepo, holidayRepo, scheduleRepo, leaveRepo, userRepo, payrollRepo, auditRepo)
+	overtimeUsecase := usecase.NewOvertimeUsecase(overtimeRepo, userRepo, payrollRepo, auditRepo)
+	reimbursementUsecase := usecase.NewReimbursementUsecase(reimbursementRepo, userRepo, payrollRepo, auditRepo)
 	payrollUsecase := usecase.NewPayrollUsecase(
 		payrollRepo, userRepo, attendanceRepo,
-		overtimeRepo, reimbursementRepo, auditRepo,
+		overtimeRepo, reimbursementRepo, holidayRepo, taxRepo, deductionRepo, allowanceRepo, compensationRepo, scheduleRepo, leaveRepo, auditRepo,
+		transactor,
 	)
+	s.failing = &failingComponent{}
+	payrollUsecase.RegisterPayComponent(s.failing)
+	companyUsecase := usecase.NewCompanyUsecase(companyRepo, auditRepo)
+	holidayUsecase := usecase.NewHolidayUsecase(holidayRepo, auditRepo, transactor)
+	taxUsecase := usecase.NewTaxUsecase(taxRepo, auditRepo)
+	deductionUsecase := usecase.NewDeductionUsecase(deductionRepo, userRepo, auditRepo)
+	allowanceUsecase := usecase.NewAllowanceUsecase(allowanceRepo, userRepo, auditRepo)
+	compensationUsecase := usecase.NewCompensationUsecase(compensationRepo, userRepo, auditRepo)
+	scheduleUsecase := usecase.NewScheduleUsecase(scheduleRepo, userRepo, auditRepo)
+	leaveUsecase := usecase.NewLeaveUsecase(leaveRepo, userRepo, attendanceRepo, holidayRepo, scheduleRepo, auditRepo, transactor)
+	payGroupUsecase := usecase.NewPayGroupUsecase(payGroupRepo, payrollRepo, auditRepo)
 
 	// Initialize handlers
 	userHandler := handler.NewUserHandler(userUsecase)
@@ -188,6 +263,15 @@ func (s *TestSuite) setupRouter() {
 	overtimeHandler := handler.NewOvertimeHandler(overtimeUsecase)
 	reimbursementHandler := handler.NewReimbursementHandler(reimbursementUsecase)
 	payrollHandler := handler.NewPayrollHandler(payrollUsecase)
+	companyHandler := handler.NewCompanyHandler(companyUsecase)
+	holidayHandler := handler.NewHolidayHandler(holidayUsecase)
+	taxHandler := handler.NewTaxHandler(taxUsecase)
+	deductionHandler := handler.NewDeductionHandler(deductionUsecase)
+	allowanceHandler := handler.NewAllowanceHandler(allowanceUsecase)
+	compensationHandler := handler.NewCompensationHandler(compensationUsecase)
+	scheduleHandler := handler.NewScheduleHandler(scheduleUsecase)
+	leaveHandler := handler.NewLeaveHandler(leaveUsecase)
+	payGroupHandler := handler.NewPayGroupHandler(payGroupUsecase)
 
 	// Setup routes
 	s.router = routes.SetupRoutes(
@@ -196,6 +280,15 @@ func (s *TestSuite) setupRouter() {
 		overtimeHandler,
 		reimbursementHandler,
 		payrollHandler,
+		companyHandler,
+		holidayHandler,
+		taxHandler,
+		deductionHandler,
+		allowanceHandler,
+		compensationHandler,
+		scheduleHandler,
+		leaveHandler,
+		payGroupHandler,
 	)
 }
 
@@ -246,6 +339,7 @@ func (s *TestSuite) createTestPayrollPeriod() *model.PayrollPeriod {
 	period := &model.PayrollPeriod{
 		StartDate:   now.AddDate(0, -1, 0),
 		EndDate:     now.AddDate(0, 0, -1),
+		Status:      model.PeriodOpen,
 		IsProcessed: false,
 	}
 	result := s.db.Create(period)
@@ -331,15 +425,30 @@ func (s *TestSuite) TestCompletePayrollWorkflow() {
 	w = s.makeRequest("POST", "/api/employee/overtime", overtimeData, s.employeeToken)
 	assert.Equal(s.T(), http.StatusCreated, w.Code, "Failed to create overtime")
 
-	// 4. Admin runs payroll
+	// 4. Admin previews payroll
 	runData := map[string]interface{}{
 		"payroll_period_id": periodID,
 	}
 
+	w = s.makeRequest("POST", "/api/admin/payroll/preview", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to preview payroll")
+
+	var previewResp map[string]interface{}
+	err = json.Unmarshal(w.Body.Bytes(), &previewResp)
+	require.NoError(s.T(), err, "Failed to parse preview response")
+	preview, _ := previewResp["data"].(map[string]interface{})
+	assert.Equal(s.T(), true, preview["preview"])
+	assert.Equal(s.T(), float64(1), preview["employee_count"])
+
+	var storedPayslips int64
+	s.db.Model(&model.Payslip{}).Where("payroll_period_id = ?", periodID).Count(&storedPayslips)
+	assert.Zero(s.T(), storedPayslips, "Preview must not store payslips")
+
+	// 5. Admin runs payroll
 	w = s.makeRequest("POST", "/api/admin/payroll/run", runData, s.adminToken)
 	assert.Equal(s.T(), http.StatusOK, w.Code, "Failed to run payroll")
 
-	// 5. Employee views payslip
+	// 6. Employee views payslip
 	w = s.makeRequest("GET", fmt.Sprintf("/api/employee/payslip?period_id=%d", periodID), nil, s.employeeToken)
 	assert.Equal(s.T(), http.StatusOK, w.Code, "Failed to get payslip")
 
@@ -348,6 +457,560 @@ func (s *TestSuite) TestCompletePayrollWorkflow() {
 	assert.NoError(s.T(), err, "Failed to parse payslip response")
 }
 
+// Payroll Reversal Test
+func (s *TestSuite) TestPayrollReversal() {
+	period := s.createTestPayrollPeriod()
+	runData := map[string]interface{}{
+		"payroll_period_id": period.ID,
+	}
+
+	w := s.makeRequest("POST", "/api/admin/payroll/run", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to run payroll: %s", w.Body.String())
+
+	w = s.makeRequest("POST", "/api/admin/payroll/reverse", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to reverse payroll: %s", w.Body.String())
+
+	var voided int64
+	s.db.Model(&model.Payslip{}).Where("payroll_period_id = ? AND status = ?", period.ID, model.PayslipVoided).Count(&voided)
+	assert.Equal(s.T(), int64(1), voided, "Reversal should keep the voided payslip")
+
+	w = s.makeRequest("GET", fmt.Sprintf("/api/employee/payslip?period_id=%d", period.ID), nil, s.employeeToken)
+	assert.Equal(s.T(), http.StatusNotFound, w.Code, "Voided payslip should not be served")
+
+	// Re-run, pay, and reversal is refused
+	w = s.makeRequest("POST", "/api/admin/payroll/run", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to re-run payroll: %s", w.Body.String())
+
+	w = s.makeRequest("POST", "/api/admin/payroll/mark-paid", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to mark payroll paid: %s", w.Body.String())
+
+	w = s.makeRequest("POST", "/api/admin/payroll/reverse", runData, s.adminToken)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Paid payroll should not be reversible")
+}
+
+// A run cannot be reversed once a later period of its pay group ran
+func (s *TestSuite) TestPayrollReversalRefusedAfterLaterRun() {
+	now := time.Now()
+	earlier := &model.PayrollPeriod{
+		StartDate: now.AddDate(0, -3, 0),
+		EndDate:   now.AddDate(0, -2, -1),
+		Status:    model.PeriodProcessed,
+	}
+	require.NoError(s.T(), s.db.Create(earlier).Error)
+
+	period := s.createTestPayrollPeriod()
+	w := s.makeRequest("POST", "/api/admin/payroll/run", map[string]interface{}{"payroll_period_id": period.ID}, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to run payroll: %s", w.Body.String())
+
+	w = s.makeRequest("POST", "/api/admin/payroll/reverse", map[string]interface{}{"payroll_period_id": earlier.ID}, s.adminToken)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Reversal should be refused after a later run: %s", w.Body.String())
+	assert.Contains(s.T(), w.Body.String(), "reverse it first")
+
+	require.NoError(s.T(), s.db.First(earlier, earlier.ID).Error)
+	assert.Equal(s.T(), model.PeriodProcessed, earlier.Status)
+}
+
+// Payroll Correction Test
+func (s *TestSuite) TestPayrollCorrection() {
+	period := s.createTestPayrollPeriod()
+	runData := map[string]interface{}{
+		"payroll_period_id": period.ID,
+	}
+
+	w := s.makeRequest("POST", "/api/admin/payroll/run", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to run payroll: %s", w.Body.String())
+
+	var original model.Payslip
+	require.NoError(s.T(), s.db.Where("payroll_period_id = ? AND user_id = ?", period.ID, s.employeeUser.ID).First(&original).Error)
+
+	// An attendance missed by the run cannot be submitted by the employee
+	// any more, but an administrator can record it
+	date := period.StartDate.AddDate(0, 0, 1)
+	for date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		date = date.AddDate(0, 0, 1)
+	}
+	attendanceData := map[string]string{
+		"date":      date.Format("2006-01-02"),
+		"check_in":  "09:00:00",
+		"check_out": "17:00:00",
+	}
+	w = s.makeRequest("POST", "/api/employee/attendance", attendanceData, s.employeeToken)
+	require.Equal(s.T(), http.StatusBadRequest, w.Code, "Attendance in a processed period should be rejected")
+	assert.Contains(s.T(), w.Body.String(), "payroll correction")
+
+	w = s.makeRequest("POST", fmt.Sprintf("/api/admin/employees/%d/attendance", s.employeeUser.ID), attendanceData, s.adminToken)
+	require.Equal(s.T(), http.StatusCreated, w.Code, "Failed to record attendance: %s", w.Body.String())
+
+	correctionData := map[string]interface{}{
+		"payroll_period_id": period.ID,
+		"user_ids":          []uint{s.employeeUser.ID},
+		"reason":            "Missed attendance",
+	}
+	w = s.makeRequest("POST", "/api/admin/payroll/corrections", correctionData, s.adminToken)
+	require.Equal(s.T(), http.StatusCreated, w.Code, "Failed to run correction: %s", w.Body.String())
+
+	var adjustment model.Payslip
+	require.NoError(s.T(), s.db.Where("payroll_period_id = ? AND kind = ?", period.ID, model.PayslipAdjustment).First(&adjustment).Error)
+	require.NotNil(s.T(), adjustment.OriginalPayslipID)
+	assert.Equal(s.T(), original.ID, *adjustment.OriginalPayslipID)
+	assert.Equal(s.T(), 1, adjustment.AttendanceDays)
+	assert.Positive(s.T(), int64(adjustment.TotalEarnings))
+
+	// Nothing left to correct
+	w = s.makeRequest("POST", "/api/admin/payroll/corrections", correctionData, s.adminToken)
+	require.Equal(s.T(), http.StatusCreated, w.Code)
+	var count int64
+	s.db.Model(&model.Payslip{}).Where("payroll_period_id = ? AND kind = ?", period.ID, model.PayslipAdjustment).Count(&count)
+	assert.Equal(s.T(), int64(1), count)
+}
+
+// Holiday Calendar Test
+func (s *TestSuite) TestHolidayCalendar() {
+	date := time.Now().AddDate(0, 0, -7)
+	for date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		date = date.AddDate(0, 0, -1)
+	}
+	holidayDate := date.Format("2006-01-02")
+
+	importData := map[string]interface{}{
+		"year": date.Year(),
+		"holidays": []map[string]string{
+			{"date": holidayDate, "name": "Test Holiday", "type": "public_holiday"},
+		},
+	}
+	w := s.makeRequest("POST", "/api/admin/holidays/import", importData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to import holidays: %s", w.Body.String())
+
+	w = s.makeRequest("GET", fmt.Sprintf("/api/admin/holidays?year=%d", date.Year()), nil, s.adminToken)
+	assert.Equal(s.T(), http.StatusOK, w.Code, "Failed to list holidays")
+	assert.Contains(s.T(), w.Body.String(), "Test Holiday")
+
+	attendanceData := map[string]string{
+		"date":      holidayDate,
+		"check_in":  "09:00:00",
+		"check_out": "17:00:00",
+	}
+	w = s.makeRequest("POST", "/api/employee/attendance", attendanceData, s.employeeToken)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Attendance on a holiday should be rejected")
+}
+
+// Leave Management Test
+func (s *TestSuite) TestLeaveRequest() {
+	period := s.createTestPayrollPeriod()
+	annual := &model.LeaveType{Code: "ANNUAL", Name: "Annual leave", Paid: true, IsActive: true}
+	require.NoError(s.T(), s.db.Create(annual).Error)
+
+	date := period.StartDate.AddDate(0, 0, 1)
+	for date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		date = date.AddDate(0, 0, 1)
+	}
+	leaveDate := date.Format("2006-01-02")
+
+	leaveData := map[string]interface{}{
+		"leave_type_id": annual.ID,
+		"start_date":    leaveDate,
+		"end_date":      leaveDate,
+		"reason":        "Family event",
+	}
+	w := s.makeRequest("POST", "/api/employee/leave", leaveData, s.employeeToken)
+	require.Equal(s.T(), http.StatusCreated, w.Code, "Failed to submit leave: %s", w.Body.String())
+
+	var request model.LeaveRequest
+	require.NoError(s.T(), s.db.Where("user_id = ?", s.employeeUser.ID).First(&request).Error)
+	assert.Equal(s.T(), 1, request.Days)
+
+	w = s.makeRequest("POST", fmt.Sprintf("/api/admin/leave-requests/%d/approve", request.ID), nil, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to approve leave: %s", w.Body.String())
+
+	attendanceData := map[string]string{
+		"date":      leaveDate,
+		"check_in":  "09:00:00",
+		"check_out": "17:00:00",
+	}
+	w = s.makeRequest("POST", "/api/employee/attendance", attendanceData, s.employeeToken)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Attendance during leave should be rejected")
+
+	runData := map[string]interface{}{
+		"payroll_period_id": period.ID,
+	}
+	w = s.makeRequest("POST", "/api/admin/payroll/run", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to run payroll: %s", w.Body.String())
+
+	var payslip model.Payslip
+	require.NoError(s.T(), s.db.Where("payroll_period_id = ? AND user_id = ?", period.ID, s.employeeUser.ID).First(&payslip).Error)
+	assert.Equal(s.T(), 1, payslip.PaidLeaveDays)
+}
+
+// Leave Balance Test
+func (s *TestSuite) TestLeaveBalance() {
+	hired := time.Now().AddDate(-2, 0, 0)
+	require.NoError(s.T(), s.db.Model(s.employeeUser).Update("hire_date", hired).Error)
+	annual := &model.LeaveType{Code: "ANNUAL", Name: "Annual leave", Paid: true, IsActive: true, AccrualDaysPerYear: 12}
+	require.NoError(s.T(), s.db.Create(annual).Error)
+
+	w := s.makeRequest("GET", "/api/employee/leave-balances", nil, s.employeeToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to get leave balances: %s", w.Body.String())
+
+	var balance model.LeaveBalance
+	require.NoError(s.T(), s.db.Where("user_id = ? AND leave_type_id = ?", s.employeeUser.ID, annual.ID).First(&balance).Error)
+	assert.Equal(s.T(), time.Now().Year(), balance.Year)
+	assert.LessOrEqual(s.T(), balance.Accrued, 12.0)
+
+	// More days than have accrued are refused
+	start := time.Date(time.Now().Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	leaveData := map[string]interface{}{
+		"leave_type_id": annual.ID,
+		"start_date":    start.Format("2006-01-02"),
+		"end_date":      start.AddDate(0, 0, 27).Format("2006-01-02"),
+	}
+	w = s.makeRequest("POST", "/api/employee/leave", leaveData, s.employeeToken)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Leave beyond the balance should be rejected")
+	assert.Contains(s.T(), w.Body.String(), "insufficient")
+}
+
+// Concurrent approvals cannot spend the same days
+func (s *TestSuite) TestConcurrentLeaveApprovals() {
+	now := time.Now()
+	require.NoError(s.T(), s.db.Model(s.employeeUser).Update("hire_date", now).Error)
+	annual := &model.LeaveType{Code: "ANNUAL", Name: "Annual leave", Paid: true, IsActive: true, AccrualDaysPerYear: 1}
+	require.NoError(s.T(), s.db.Create(annual).Error)
+	balance := &model.LeaveBalance{UserID: s.employeeUser.ID, LeaveTypeID: annual.ID, Year: now.Year(), CarriedOver: 3}
+	require.NoError(s.T(), s.db.Create(balance).Error)
+
+	start := time.Date(now.Year(), time.January, 2, 0, 0, 0, 0, time.UTC)
+	requests := make([]model.LeaveRequest, 2)
+	for i := range requests {
+		requests[i] = model.LeaveRequest{
+			UserID:      s.employeeUser.ID,
+			LeaveTypeID: annual.ID,
+			StartDate:   start,
+			EndDate:     start.AddDate(0, 0, 2),
+			Days:        3,
+			Status:      model.LeavePending,
+		}
+		require.NoError(s.T(), s.db.Omit("User", "LeaveType").Create(&requests[i]).Error)
+	}
+
+	codes := make([]int, len(requests))
+	var wg sync.WaitGroup
+	for i := range requests {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			path := fmt.Sprintf("/api/admin/leave-requests/%d/approve", requests[i].ID)
+			codes[i] = s.makeRequest("POST", path, map[string]string{"note": "ok"}, s.adminToken).Code
+		}(i)
+	}
+	wg.Wait()
+
+	assert.ElementsMatch(s.T(), []int{http.StatusOK, http.StatusBadRequest}, codes)
+	require.NoError(s.T(), s.db.First(balance, balance.ID).Error)
+	assert.Equal(s.T(), 3.0, balance.Used)
+}
+
+// Record Linking Test
+func (s *TestSuite) TestPayrollLinksRecords() {
+	period := s.createTestPayrollPeriod()
+
+	date := period.StartDate.AddDate(0, 0, 2)
+	for date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		date = date.AddDate(0, 0, 1)
+	}
+	attendanceData := map[string]string{"date": date.Format("2006-01-02"), "check_in": "09:00:00", "check_out": "17:00:00"}
+	w := s.makeRequest("POST", "/api/employee/attendance", attendanceData, s.employeeToken)
+	require.Equal(s.T(), http.StatusCreated, w.Code, "Failed to create attendance: %s", w.Body.String())
+
+	var attendance model.Attendance
+	require.NoError(s.T(), s.db.Where("user_id = ?", s.employeeUser.ID).First(&attendance).Error)
+	assert.Nil(s.T(), attendance.PayrollPeriodID)
+
+	runData := map[string]interface{}{"payroll_period_id": period.ID}
+	w = s.makeRequest("POST", "/api/admin/payroll/run", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to run payroll: %s", w.Body.String())
+
+	require.NoError(s.T(), s.db.First(&attendance, attendance.ID).Error)
+	require.NotNil(s.T(), attendance.PayrollPeriodID)
+	assert.Equal(s.T(), period.ID, *attendance.PayrollPeriodID)
+	assert.True(s.T(), attendance.IsProcessed)
+
+	var payslip model.Payslip
+	require.NoError(s.T(), s.db.Preload("Records").Where("payroll_period_id = ? AND user_id = ?", period.ID, s.employeeUser.ID).First(&payslip).Error)
+	assert.Equal(s.T(), []uint{attendance.ID}, payslip.RecordIDs(model.PayslipRecordAttendance))
+
+	w = s.makeRequest("GET", fmt.Sprintf("/api/employee/payslip?period_id=%d", period.ID), nil, s.employeeToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to get payslip: %s", w.Body.String())
+	assert.Contains(s.T(), w.Body.String(), `"record_type":"attendance"`)
+
+	// Reversal releases the records
+	w = s.makeRequest("POST", "/api/admin/payroll/reverse", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to reverse payroll: %s", w.Body.String())
+
+	require.NoError(s.T(), s.db.First(&attendance, attendance.ID).Error)
+	assert.Nil(s.T(), attendance.PayrollPeriodID)
+	assert.False(s.T(), attendance.IsProcessed)
+}
+
+// Payroll Period Status Test
+func (s *TestSuite) TestPayrollPeriodStatus() {
+	period := s.createTestPayrollPeriod()
+	require.Equal(s.T(), model.PeriodOpen, period.Status)
+
+	// Overlapping periods are refused
+	periodData := map[string]string{
+		"start_date": period.EndDate.AddDate(0, 0, -3).Format("2006-01-02"),
+		"end_date":   period.EndDate.AddDate(0, 0, 10).Format("2006-01-02"),
+	}
+	w := s.makeRequest("POST", "/api/admin/payroll-periods", periodData, s.adminToken)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Overlapping period should be rejected")
+	assert.Contains(s.T(), w.Body.String(), "overlaps")
+
+	// Locked periods can run, drafts cannot
+	transitionPath := fmt.Sprintf("/api/admin/payroll-periods/%d/transition", period.ID)
+	w = s.makeRequest("POST", transitionPath, map[string]string{"status": "draft"}, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to move period to draft: %s", w.Body.String())
+
+	runData := map[string]interface{}{"payroll_period_id": period.ID}
+	w = s.makeRequest("POST", "/api/admin/payroll/run", runData, s.adminToken)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Draft period should not run")
+
+	w = s.makeRequest("POST", transitionPath, map[string]string{"status": "locked"}, s.adminToken)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Draft period cannot be locked")
+
+	for _, status := range []string{"open", "locked"} {
+		w = s.makeRequest("POST", transitionPath, map[string]string{"status": status}, s.adminToken)
+		require.Equal(s.T(), http.StatusOK, w.Code, "Failed to move period to %s: %s", status, w.Body.String())
+	}
+
+	w = s.makeRequest("POST", "/api/admin/payroll/run", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to run payroll: %s", w.Body.String())
+
+	w = s.makeRequest("POST", transitionPath, map[string]string{"status": "closed"}, s.adminToken)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Unpaid period cannot be closed")
+
+	w = s.makeRequest("POST", "/api/admin/payroll/mark-paid", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to mark payroll paid: %s", w.Body.String())
+
+	w = s.makeRequest("POST", transitionPath, map[string]string{"status": "closed"}, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to close period: %s", w.Body.String())
+
+	var stored model.PayrollPeriod
+	require.NoError(s.T(), s.db.First(&stored, period.ID).Error)
+	assert.Equal(s.T(), model.PeriodClosed, stored.Status)
+	assert.True(s.T(), stored.IsProcessed)
+	assert.True(s.T(), stored.IsPaid)
+
+	w = s.makeRequest("GET", "/api/admin/payroll-periods?status=closed", nil, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to list periods: %s", w.Body.String())
+	assert.Contains(s.T(), w.Body.String(), fmt.Sprintf(`"id":%d`, period.ID))
+}
+
+// Submission Lock Test
+func (s *TestSuite) TestSubmissionsInProcessedPeriod() {
+	period := s.createTestPayrollPeriod()
+	runData := map[string]interface{}{"payroll_period_id": period.ID}
+	w := s.makeRequest("POST", "/api/admin/payroll/run", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to run payroll: %s", w.Body.String())
+
+	date := period.StartDate.AddDate(0, 0, 7)
+	for date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		date = date.AddDate(0, 0, 1)
+	}
+	processedDate := date.Format("2006-01-02")
+
+	attendanceData := map[string]string{"date": processedDate, "check_in": "09:00:00", "check_out": "17:00:00"}
+	w = s.makeRequest("POST", "/api/employee/attendance", attendanceData, s.employeeToken)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Attendance in a processed period should be rejected")
+	assert.Contains(s.T(), w.Body.String(), "payroll correction")
+
+	overtimeData := map[string]interface{}{"date": processedDate, "hours": 2, "description": "Late fix"}
+	w = s.makeRequest("POST", "/api/employee/overtime", overtimeData, s.employeeToken)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Overtime in a processed period should be rejected")
+
+	reimbursementData := map[string]interface{}{"amount": 50000, "description": "Taxi", "date": processedDate}
+	w = s.makeRequest("POST", "/api/employee/reimbursement", reimbursementData, s.employeeToken)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Reimbursement in a processed period should be rejected")
+
+	// Draft periods are not open yet
+	now := time.Now()
+	draft := &model.PayrollPeriod{StartDate: now, EndDate: now.AddDate(0, 0, 6), Status: model.PeriodDraft}
+	require.NoError(s.T(), s.db.Create(draft).Error)
+	delete(reimbursementData, "date")
+	w = s.makeRequest("POST", "/api/employee/reimbursement", reimbursementData, s.employeeToken)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Reimbursement in a draft period should be rejected")
+	assert.Contains(s.T(), w.Body.String(), "not open yet")
+
+	// Once opened, the period takes submissions for today
+	draft.SetStatus(model.PeriodOpen, now)
+	require.NoError(s.T(), s.db.Save(draft).Error)
+	w = s.makeRequest("POST", "/api/employee/reimbursement", reimbursementData, s.employeeToken)
+	assert.Equal(s.T(), http.StatusCreated, w.Code, "Failed to submit reimbursement: %s", w.Body.String())
+
+	// Future dates need a submission window
+	overtimeData["date"] = time.Now().AddDate(0, 0, 3).Format("2006-01-02")
+	w = s.makeRequest("POST", "/api/employee/overtime", overtimeData, s.employeeToken)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Future overtime should be rejected")
+	assert.Contains(s.T(), w.Body.String(), "future")
+}
+
+// Pay Group Test
+func (s *TestSuite) TestPayGroupPayroll() {
+	groupData := map[string]interface{}{
+		"name":        "Weekly workers",
+		"frequency":   "weekly",
+		"anchor_date": "2024-01-01",
+	}
+	w := s.makeRequest("POST", "/api/admin/pay-groups", groupData, s.adminToken)
+	require.Equal(s.T(), http.StatusCreated, w.Code, "Failed to create pay group: %s", w.Body.String())
+
+	var group model.PayGroup
+	require.NoError(s.T(), s.db.Where("name = ?", "Weekly workers").First(&group).Error)
+
+	generateData := map[string]string{"from": "2024-03-01", "to": "2024-03-31"}
+	path := fmt.Sprintf("/api/admin/pay-groups/%d/periods", group.ID)
+	w = s.makeRequest("POST", path, generateData, s.adminToken)
+	require.Equal(s.T(), http.StatusCreated, w.Code, "Failed to generate periods: %s", w.Body.String())
+
+	var periods []model.PayrollPeriod
+	require.NoError(s.T(), s.db.Where("pay_group_id = ?", group.ID).Order("start_date").Find(&periods).Error)
+	require.Len(s.T(), periods, 4)
+	assert.Equal(s.T(), "2024-03-04", periods[0].StartDate.Format("2006-01-02"))
+
+	// Generating the same range again creates nothing
+	w = s.makeRequest("POST", path, generateData, s.adminToken)
+	require.Equal(s.T(), http.StatusCreated, w.Code)
+	var count int64
+	s.db.Model(&model.PayrollPeriod{}).Where("pay_group_id = ?", group.ID).Count(&count)
+	assert.Equal(s.T(), int64(4), count)
+
+	// The employee is paid by the group's periods only
+	runData := map[string]interface{}{"payroll_period_id": periods[0].ID}
+	w = s.makeRequest("POST", "/api/admin/payroll/preview", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to preview payroll: %s", w.Body.String())
+	assert.Contains(s.T(), w.Body.String(), `"employee_count":0`)
+
+	employeeData := map[string]interface{}{"pay_group_id": group.ID}
+	w = s.makeRequest("PUT", fmt.Sprintf("/api/admin/employees/%d", s.employeeUser.ID), employeeData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to assign pay group: %s", w.Body.String())
+
+	w = s.makeRequest("POST", "/api/admin/payroll/preview", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to preview payroll: %s", w.Body.String())
+	assert.Contains(s.T(), w.Body.String(), `"employee_count":1`)
+
+	monthly := s.createTestPayrollPeriod()
+	runData = map[string]interface{}{"payroll_period_id": monthly.ID}
+	w = s.makeRequest("POST", "/api/admin/payroll/preview", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to preview payroll: %s", w.Body.String())
+	assert.Contains(s.T(), w.Body.String(), `"employee_count":0`)
+}
+
+// Transactional Payroll Run Test
+func (s *TestSuite) TestPayrollRunAllOrNothing() {
+	period := s.createTestPayrollPeriod()
+	runData := map[string]interface{}{"payroll_period_id": period.ID}
+
+	// The employee leaves in the period with leave to pay out
+	require.NoError(s.T(), s.db.Model(s.employeeUser).Updates(map[string]interface{}{
+		"hire_date":        time.Now().AddDate(-2, 0, 0),
+		"termination_date": period.EndDate,
+	}).Error)
+	annual := &model.LeaveType{Code: "ANNUAL", Name: "Annual leave", Paid: true, IsActive: true, AccrualDaysPerYear: 12, PayOutOnTermination: true}
+	require.NoError(s.T(), s.db.Create(annual).Error)
+
+	// One employee failing stores nothing and reports who failed
+	s.failing.userID = s.employeeUser.ID
+	defer func() { s.failing.userID = 0 }()
+
+	w := s.makeRequest("POST", "/api/admin/payroll/run", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusUnprocessableEntity, w.Code, "Expected payroll run to fail: %s", w.Body.String())
+	assert.Contains(s.T(), w.Body.String(), `"username":"employee"`)
+	assert.Contains(s.T(), w.Body.String(), `"stage":"calculate"`)
+	assert.Contains(s.T(), w.Body.String(), `"error":"test failure"`)
+
+	var count int64
+	s.db.Model(&model.Payslip{}).Where("payroll_period_id = ?", period.ID).Count(&count)
+	assert.Equal(s.T(), int64(0), count)
+	require.NoError(s.T(), s.db.First(period, period.ID).Error)
+	assert.Equal(s.T(), model.PeriodOpen, period.Status)
+	s.db.Model(&model.LeaveBalance{}).Where("user_id = ?", s.employeeUser.ID).Count(&count)
+	assert.Equal(s.T(), int64(0), count, "A failed run must not save leave balances")
+
+	// The preview reports the same failure
+	w = s.makeRequest("POST", "/api/admin/payroll/preview", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to preview payroll: %s", w.Body.String())
+	assert.Contains(s.T(), w.Body.String(), `"failures":[`)
+
+	// Once the employee can be paid the run stores every payslip
+	s.failing.userID = 0
+	w = s.makeRequest("POST", "/api/admin/payroll/run", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to run payroll: %s", w.Body.String())
+	assert.Contains(s.T(), w.Body.String(), `"processed":1`)
+
+	s.db.Model(&model.Payslip{}).Where("payroll_period_id = ?", period.ID).Count(&count)
+	assert.Equal(s.T(), int64(1), count)
+}
+
+// A payslip the database rejects fails the run and reports its employee
+func (s *TestSuite) TestPayrollRunReportsStoreFailure() {
+	period := s.createTestPayrollPeriod()
+	runData := map[string]interface{}{"payroll_period_id": period.ID}
+
+	require.NoError(s.T(), s.db.Exec(fmt.Sprintf("ALTER TABLE payslips ADD CONSTRAINT test_reject_employee CHECK (user_id <> %d)", s.employeeUser.ID)).Error)
+	defer s.db.Exec("ALTER TABLE payslips DROP CONSTRAINT test_reject_employee")
+
+	w := s.makeRequest("POST", "/api/admin/payroll/run", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusUnprocessableEntity, w.Code, "Expected payroll run to fail: %s", w.Body.String())
+	assert.Contains(s.T(), w.Body.String(), `"username":"employee"`)
+	assert.Contains(s.T(), w.Body.String(), `"stage":"store"`)
+
+	var count int64
+	s.db.Model(&model.Payslip{}).Where("payroll_period_id = ?", period.ID).Count(&count)
+	assert.Equal(s.T(), int64(0), count)
+	require.NoError(s.T(), s.db.First(period, period.ID).Error)
+	assert.Equal(s.T(), model.PeriodOpen, period.Status)
+}
+
+// Leave Payout Test
+func (s *TestSuite) TestLeavePayoutSavedOnlyByRun() {
+	period := s.createTestPayrollPeriod()
+	hired := time.Now().AddDate(-2, 0, 0)
+	require.NoError(s.T(), s.db.Model(s.employeeUser).Updates(map[string]interface{}{
+		"hire_date":        hired,
+		"termination_date": period.EndDate,
+	}).Error)
+	annual := &model.LeaveType{Code: "ANNUAL", Name: "Annual leave", Paid: true, IsActive: true, AccrualDaysPerYear: 12, PayOutOnTermination: true}
+	require.NoError(s.T(), s.db.Create(annual).Error)
+
+	// A preview pays the balance out without saving it
+	runData := map[string]interface{}{"payroll_period_id": period.ID}
+	w := s.makeRequest("POST", "/api/admin/payroll/preview", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to preview payroll: %s", w.Body.String())
+
+	var count int64
+	s.db.Model(&model.LeaveBalance{}).Where("user_id = ?", s.employeeUser.ID).Count(&count)
+	assert.Equal(s.T(), int64(0), count)
+
+	// The run saves it with what it paid out
+	w = s.makeRequest("POST", "/api/admin/payroll/run", runData, s.adminToken)
+	require.Equal(s.T(), http.StatusOK, w.Code, "Failed to run payroll: %s", w.Body.String())
+
+	var balance model.LeaveBalance
+	require.NoError(s.T(), s.db.Where("user_id = ? AND leave_type_id = ?", s.employeeUser.ID, annual.ID).First(&balance).Error)
+	assert.Greater(s.T(), balance.PaidOut, 0.0)
+
+	var item model.PayslipItem
+	require.NoError(s.T(), s.db.Where("code = ?", model.PayCodeLeavePayout).First(&item).Error)
+	require.NotNil(s.T(), item.LeaveBalanceID)
+	assert.Equal(s.T(), balance.ID, *item.LeaveBalanceID)
+}
+
+// Employee Update Test
+func (s *TestSuite) TestUpdateEmployeeRejectsAdmin() {
+	employeeData := map[string]interface{}{"grade": "G1"}
+	w := s.makeRequest("PUT", fmt.Sprintf("/api/admin/employees/%d", s.adminUser.ID), employeeData, s.adminToken)
+	assert.Equal(s.T(), http.StatusBadRequest, w.Code, "Expected admin update to be rejected: %s", w.Body.String())
+	assert.Contains(s.T(), w.Body.String(), "employee not found")
+}
+
 func TestIntegrationSuite(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
@@ -0,0 +1,209 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"payroll/database"
+	"payroll/domain/dto"
+	"payroll/domain/model"
+	"payroll/repositories"
+	"payroll/usecase"
+)
+
+// benchmarkEmployees is the headcount BenchmarkPayrollRun pays.
+const benchmarkEmployees = 10000
+
+// benchmarkRaiseEvery is how often an employee gets a raise backdated into
+// the period paid before the benchmark's, so their run pays retro pay.
+const benchmarkRaiseEvery = 100
+
+// BenchmarkPayrollRun runs payroll for a month of benchmarkEmployees
+// employees, each with a salary history, a payslip for the month before, a
+// week of attendance, an overtime record and a reimbursement, and reports
+// how many employees are paid per second with different numbers of workers.
+// Every benchmarkRaiseEvery-th employee is also owed retro pay. Run it with
+//
+//	go test ./tests/integration -run '^$' -bench PayrollRun -benchtime 3x
+func BenchmarkPayrollRun(b *testing.B) {
+	if testing.Short() {
+		b.Skip("Skipping integration benchmark in short mode")
+	}
+
+	container := startPostgres(b)
+	b.Cleanup(func() {
+		if err := container.Terminate(context.Background()); err != nil {
+			b.Logf("Warning: failed to terminate container: %v", err)
+		}
+	})
+	db := connectPostgres(b, container)
+	database.Migrate(db)
+	require.NoError(b, database.SeedTaxTables(db), "Failed to seed tax tables")
+
+	admin, period := seedPayrollBenchmark(b, db)
+	payrollUsecase := newBenchmarkPayrollUsecase(db)
+	req := &dto.PayrollRunRequest{PayrollPeriodID: period.ID}
+
+	for _, workers := range []int{1, 8, 32} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			payrollUsecase.SetWorkers(workers)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				report, err := payrollUsecase.RunPayroll(req, admin.ID, "127.0.0.1", "benchmark")
+				require.NoError(b, err)
+				require.Equal(b, benchmarkEmployees, report.Processed)
+
+				b.StopTimer()
+				resetPayrollBenchmark(b, db, period)
+				b.StartTimer()
+			}
+			b.ReportMetric(float64(benchmarkEmployees*b.N)/b.Elapsed().Seconds(), "employees/s")
+		})
+	}
+}
+
+// seedPayrollBenchmark creates the employees, their salary history, the
+// processed period before the one BenchmarkPayrollRun pays with their
+// payslips, their records and the open period itself.
+func seedPayrollBenchmark(b *testing.B, db *gorm.DB) (*model.User, *model.PayrollPeriod) {
+	admin := &model.User{Username: "admin", Password: "-", Role: model.RoleAdmin}
+	require.NoError(b, db.Create(admin).Error, "Failed to create admin user")
+
+	users := make([]model.User, benchmarkEmployees)
+	for i := range users {
+		users[i] = model.User{
+			Username: fmt.Sprintf("employee%05d", i),
+			Password: "-",
+			Salary:   model.Money(5_000_000 + 1_000*i),
+			Role:     model.RoleEmployee,
+		}
+	}
+	require.NoError(b, db.CreateInBatches(&users, 1000).Error, "Failed to create employees")
+
+	// Pay February with the salaries in force then
+	febStart := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	febEnd := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)
+	february := &model.PayrollPeriod{StartDate: febStart, EndDate: febEnd, Status: model.PeriodProcessed}
+	require.NoError(b, db.Create(february).Error, "Failed to create previous payroll period")
+
+	var compensations, raises []model.Compensation
+	payslips := make([]model.Payslip, 0, len(users))
+	for i, user := range users {
+		compensations = append(compensations, model.Compensation{
+			UserID:        user.ID,
+			Salary:        user.Salary,
+			EffectiveDate: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Reason:        "Hire",
+		})
+		payslips = append(payslips, model.Payslip{
+			UserID:          user.ID,
+			PayrollPeriodID: february.ID,
+			Kind:            model.PayslipRegular,
+			Status:          model.PayslipActive,
+			BaseSalary:      user.Salary,
+			WorkingDays:     21,
+			TotalEarnings:   user.Salary,
+			TotalPay:        user.Salary,
+			Items: []model.PayslipItem{{
+				Code:     model.PayCodeBasePay,
+				Label:    "Base pay",
+				Type:     model.PayslipItemEarning,
+				Quantity: 1,
+				Rate:     user.Salary,
+				Amount:   user.Salary,
+				Taxable:  true,
+			}},
+			SalarySegments: []model.PayslipSalarySegment{{
+				StartDate: febStart,
+				EndDate:   febEnd,
+				Salary:    user.Salary,
+			}},
+		})
+
+		// A raise backdated into February, granted after it was paid
+		if i%benchmarkRaiseEvery == 0 {
+			raises = append(raises, model.Compensation{
+				UserID:        user.ID,
+				Salary:        user.Salary + user.Salary/10,
+				EffectiveDate: time.Date(2024, time.February, 15, 0, 0, 0, 0, time.UTC),
+				Reason:        "Promotion",
+			})
+		}
+	}
+	require.NoError(b, db.CreateInBatches(&compensations, 1000).Error, "Failed to create salary history")
+	require.NoError(b, db.Omit("User", "PayrollPeriod").CreateInBatches(&payslips, 500).Error, "Failed to create previous payslips")
+	require.NoError(b, db.CreateInBatches(&raises, 1000).Error, "Failed to create raises")
+
+	start := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	var attendances []model.Attendance
+	var overtimes []model.Overtime
+	var reimbursements []model.Reimbursement
+	for _, user := range users {
+		for day := 4; day <= 8; day++ {
+			date := time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC)
+			checkOut := date.Add(17 * time.Hour)
+			attendances = append(attendances, model.Attendance{
+				UserID:       user.ID,
+				Date:         date,
+				CheckIn:      date.Add(9 * time.Hour),
+				CheckOut:     &checkOut,
+				WorkingHours: 8,
+			})
+		}
+		overtimes = append(overtimes, model.Overtime{
+			UserID:      user.ID,
+			Date:        time.Date(2024, time.March, 6, 0, 0, 0, 0, time.UTC),
+			Hours:       2,
+			Description: "Month-end close",
+		})
+		reimbursements = append(reimbursements, model.Reimbursement{
+			UserID:      user.ID,
+			Date:        time.Date(2024, time.March, 7, 0, 0, 0, 0, time.UTC),
+			Amount:      150_000,
+			Description: "Client visit",
+		})
+	}
+	require.NoError(b, db.Omit("User", "PayrollPeriod").CreateInBatches(&attendances, 1000).Error, "Failed to create attendance")
+	require.NoError(b, db.Omit("User", "PayrollPeriod").CreateInBatches(&overtimes, 1000).Error, "Failed to create overtime")
+	require.NoError(b, db.Omit("User", "PayrollPeriod").CreateInBatches(&reimbursements, 1000).Error, "Failed to create reimbursements")
+
+	period := &model.PayrollPeriod{StartDate: start, EndDate: end, Status: model.PeriodOpen}
+	require.NoError(b, db.Create(period).Error, "Failed to create payroll period")
+	return admin, period
+}
+
+// resetPayrollBenchmark undoes a benchmark run so the next one pays the
+// same records again. The payslips of the previous period are kept.
+func resetPayrollBenchmark(b *testing.B, db *gorm.DB, period *model.PayrollPeriod) {
+	statements := []string{
+		"DELETE FROM payslip_items WHERE payslip_id IN (SELECT id FROM payslips WHERE payroll_period_id = ?)",
+		"DELETE FROM payslip_records WHERE payslip_id IN (SELECT id FROM payslips WHERE payroll_period_id = ?)",
+		"DELETE FROM payslip_salary_segments WHERE payslip_id IN (SELECT id FROM payslips WHERE payroll_period_id = ?)",
+		"DELETE FROM payslips WHERE payroll_period_id = ?",
+		"UPDATE attendances SET payroll_period_id = NULL, is_processed = false WHERE payroll_period_id = ?",
+		"UPDATE overtimes SET payroll_period_id = NULL, is_processed = false WHERE payroll_period_id = ?",
+		"UPDATE reimbursements SET payroll_period_id = NULL, is_processed = false WHERE payroll_period_id = ?",
+	}
+	for _, statement := range statements {
+		require.NoError(b, db.Exec(statement, period.ID).Error, "Failed to reset payroll run")
+	}
+
+	period.SetStatus(model.PeriodOpen, time.Now())
+	require.NoError(b, db.Omit("PayGroup").Save(period).Error, "Failed to reopen payroll period")
+}
+
+func newBenchmarkPayrollUsecase(db *gorm.DB) *usecase.PayrollUsecase {
+	repos := repositories.NewRepositories(db)
+	return usecase.NewPayrollUsecase(
+		repos.Payroll, repos.User, repos.Attendance,
+		repos.Overtime, repos.Reimbursement, repos.Holiday, repos.Tax, repos.Deduction, repos.Allowance, repos.Compensation, repos.Schedule, repos.Leave, repos.Audit,
+		repositories.NewTransactor(db),
+	)
+}
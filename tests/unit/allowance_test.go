@@ -0,0 +1,41 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"payroll/domain/model"
+	"payroll/usecase"
+)
+
+func TestAllowanceComponent(t *testing.T) {
+	positionAmount := model.Money(1_500_000)
+	pc := newPayContext()
+	pc.Attendances = make([]model.Attendance, 20)
+	monday := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	pc.Overtimes = []model.Overtime{
+		{Date: monday, Hours: 1},
+		{Date: monday, Hours: 2},
+		{Date: monday.AddDate(0, 0, 1), Hours: 1},
+	}
+	pc.Allowances = []model.AllowanceAssignment{
+		{Allowance: model.Allowance{Name: "Position", Basis: model.AllowanceFixed, Amount: 1_000_000, Taxable: true}, Amount: &positionAmount},
+		{Allowance: model.Allowance{Name: "Transport", Basis: model.AllowancePerAttendanceDay, Amount: 25_000, Taxable: true}},
+		{Allowance: model.Allowance{Name: "Overtime meal", Basis: model.AllowancePerOvertimeDay, Amount: 30_000}},
+	}
+
+	items, err := usecase.AllowanceComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	assert.Equal(t, "Position", items[0].Label)
+	assert.Equal(t, model.Money(1_500_000), items[0].Amount, "assignment amount overrides the catalog")
+	assert.Equal(t, model.Money(500_000), items[1].Amount, "20 attended days")
+	assert.Equal(t, model.Money(60_000), items[2].Amount, "2 overtime days")
+	assert.False(t, items[2].Taxable)
+
+	assert.Equal(t, model.Money(5_900_000), pc.MonthlyWage(), "fixed allowances are part of the wage")
+}
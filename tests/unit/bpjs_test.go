@@ -0,0 +1,57 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"payroll/domain/model"
+	"payroll/usecase"
+)
+
+func TestBPJSComponent(t *testing.T) {
+	pc := newPayContext()
+	pc.User.Salary = 15_000_000
+	pc.User.Company = &model.Company{JKKRiskClass: model.JKKRiskMedium}
+
+	items, err := usecase.BPJSComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	pc.Items = items
+
+	amounts := make(map[string]model.Money)
+	for _, item := range items {
+		amounts[item.Code] = item.Amount
+	}
+
+	// Health and pension contributions stop at their wage caps.
+	assert.Equal(t, model.Money(120_000), amounts[model.PayCodeBPJSHealthEmployee])
+	assert.Equal(t, model.Money(300_000), amounts[model.PayCodeBPJSJHTEmployee])
+	assert.Equal(t, model.Money(105_474), amounts[model.PayCodeBPJSPensionEmployee])
+	assert.Equal(t, model.Money(480_000), amounts[model.PayCodeBPJSHealthEmployer])
+	assert.Equal(t, model.Money(555_000), amounts[model.PayCodeBPJSJHTEmployer])
+	assert.Equal(t, model.Money(210_948), amounts[model.PayCodeBPJSPensionEmployer])
+	assert.Equal(t, model.Money(133_500), amounts[model.PayCodeBPJSJKK])
+	assert.Equal(t, model.Money(45_000), amounts[model.PayCodeBPJSJKM])
+
+	assert.Equal(t, model.Money(525_474), pc.Total(model.PayslipItemDeduction))
+	assert.Equal(t, model.Money(1_424_448), pc.Total(model.PayslipItemEmployerCost))
+	assert.Equal(t, model.Money(405_474), pc.TaxDeductible(), "JHT and JP employee shares")
+	assert.Equal(t, model.Money(658_500), pc.TaxableIncome(), "health, JKK and JKM employer shares")
+	assert.Equal(t, model.Money(-525_474), pc.NetPay(), "employer cost does not change net pay")
+}
+
+func TestBPJSComponentDefaultsToVeryLowRisk(t *testing.T) {
+	pc := newPayContext()
+	pc.User.Salary = 5_000_000
+
+	items, err := usecase.BPJSComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	for _, item := range items {
+		if item.Code == model.PayCodeBPJSJKK {
+			assert.Equal(t, model.Money(12_000), item.Amount)
+			return
+		}
+	}
+	t.Fatal("no JKK line")
+}
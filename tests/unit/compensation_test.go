@@ -0,0 +1,71 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"payroll/domain/model"
+	"payroll/usecase"
+)
+
+func TestBasePayComponentSplitsMidPeriodRaise(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	raise := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+
+	pc := newPayContext()
+	pc.ProrationPolicy = model.ProrationWorkingDays
+	pc.WorkingDays = 21
+	pc.CalendarDays = 30
+	pc.SalarySegments = []usecase.SalarySegment{
+		{StartDate: start, EndDate: raise.AddDate(0, 0, -1), Salary: 6_300_000},
+		{StartDate: raise, EndDate: end, Salary: 8_400_000},
+	}
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			pc.Attendances = append(pc.Attendances, model.Attendance{Date: d})
+		}
+	}
+
+	items, err := usecase.BasePayComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	// 10 of 21 working days at the old salary, 11 at the new one.
+	assert.Equal(t, "Base pay (2025-06-01 to 2025-06-15)", items[0].Label)
+	assert.Equal(t, model.Money(3_000_000), items[0].Amount)
+	assert.Equal(t, model.Money(4_400_000), items[1].Amount)
+	assert.Equal(t, model.Money(8_400_000), pc.MonthlyWage(), "contributions use the salary at period end")
+}
+
+func TestProrateSegmentSalaryMinusAbsences(t *testing.T) {
+	period := usecase.ProrationBasis{PaidDays: 21, WorkingDays: 22, CalendarDays: 30, Divisor: 22}
+	segment := usecase.ProrationBasis{PaidDays: 10, WorkingDays: 11, CalendarDays: 15, Divisor: 22}
+
+	// Half the salary for half the calendar days, less one absent day.
+	amount, rate := usecase.ProrateSegment(model.ProrationSalaryMinusAbsences, 6_600_000, segment, period)
+	assert.Equal(t, model.Money(3_000_000), amount)
+	assert.Equal(t, model.Money(300_000), rate)
+}
+
+func TestSalarySegmentsBeforeFirstEntryUseFallback(t *testing.T) {
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)
+	raise := []model.Compensation{{Salary: 6_000_000, EffectiveDate: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)}}
+
+	// A raise scheduled for December does not pay September at the new salary.
+	segments := usecase.SalarySegments(raise, 5_000_000, start, end)
+	require.Len(t, segments, 1)
+	assert.Equal(t, model.Money(5_000_000), segments[0].Salary)
+
+	// A history starting mid-period pays the days before it at the fallback.
+	started := []model.Compensation{{Salary: 6_000_000, EffectiveDate: time.Date(2026, 9, 16, 0, 0, 0, 0, time.UTC)}}
+	segments = usecase.SalarySegments(started, 5_000_000, start, end)
+	require.Len(t, segments, 2)
+	assert.Equal(t, model.Money(5_000_000), segments[0].Salary)
+	assert.Equal(t, time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC), segments[0].EndDate)
+	assert.Equal(t, model.Money(6_000_000), segments[1].Salary)
+}
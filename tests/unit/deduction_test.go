@@ -0,0 +1,92 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"payroll/domain/model"
+	"payroll/usecase"
+)
+
+func TestDeductionComponentRespectsNetPayFloor(t *testing.T) {
+	pc := newPayContext()
+	pc.Items = []model.PayslipItem{{Type: model.PayslipItemEarning, Amount: 3_000_000}}
+	pc.NetPayFloor = 2_000_000
+	pc.Deductions = []model.Deduction{
+		{BaseModel: model.BaseModel{ID: 1}, Type: model.DeductionRecurring, Description: "Cooperative", Amount: 600_000, Status: model.DeductionActive},
+		{BaseModel: model.BaseModel{ID: 2}, Type: model.DeductionOneOff, Description: "Uniform", Amount: 500_000, RemainingBalance: 500_000, Status: model.DeductionActive},
+	}
+
+	items, err := usecase.DeductionComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, model.Money(600_000), items[0].Amount)
+	assert.Equal(t, model.Money(400_000), items[1].Amount)
+
+	require.Len(t, pc.DeductionTransactions, 2)
+	assert.Equal(t, model.Money(0), pc.DeductionTransactions[0].Shortfall)
+	assert.Equal(t, model.Money(100_000), pc.DeductionTransactions[1].Shortfall)
+}
+
+func TestLoanInstallmentsPayOff(t *testing.T) {
+	loan := model.Deduction{
+		Type:             model.DeductionLoan,
+		Principal:        1_000_000,
+		InstallmentCount: 3,
+		Amount:           333_333,
+		RemainingBalance: 1_000_000,
+		Status:           model.DeductionActive,
+	}
+
+	// First period: the floor leaves room for 200,000 only.
+	assert.Equal(t, model.Money(333_333), loan.AmountDue())
+	loan.Apply(&model.DeductionTransaction{DueAmount: 333_333, AppliedAmount: 200_000, Shortfall: 133_333})
+	assert.Equal(t, model.Money(800_000), loan.RemainingBalance)
+
+	// Second period: the installment plus the carried shortfall.
+	assert.Equal(t, model.Money(466_666), loan.AmountDue())
+	loan.Apply(&model.DeductionTransaction{DueAmount: 466_666, AppliedAmount: 466_666})
+
+	// Last installment settles the balance, rounding included.
+	assert.Equal(t, model.Money(333_334), loan.AmountDue())
+	loan.Apply(&model.DeductionTransaction{DueAmount: 333_334, AppliedAmount: 333_334})
+	assert.Equal(t, model.Money(0), loan.RemainingBalance)
+	assert.Equal(t, model.DeductionCompleted, loan.Status)
+	assert.Equal(t, model.Money(0), loan.AmountDue())
+}
+
+func TestRecurringDeductionStopsAfterCount(t *testing.T) {
+	deduction := model.Deduction{Type: model.DeductionRecurring, Amount: 100_000, InstallmentCount: 2, Status: model.DeductionActive}
+
+	deduction.Apply(&model.DeductionTransaction{DueAmount: 100_000, AppliedAmount: 100_000})
+	deduction.Apply(&model.DeductionTransaction{DueAmount: 100_000, AppliedAmount: 50_000, Shortfall: 50_000})
+	assert.Equal(t, model.DeductionActive, deduction.Status, "shortfall still owed")
+	assert.Equal(t, model.Money(50_000), deduction.AmountDue())
+
+	deduction.Apply(&model.DeductionTransaction{DueAmount: 50_000, AppliedAmount: 50_000})
+	assert.Equal(t, model.DeductionCompleted, deduction.Status)
+}
+
+func TestDeductionRevertRestoresBalance(t *testing.T) {
+	loan := model.Deduction{
+		Type:                model.DeductionLoan,
+		InstallmentCount:    2,
+		InstallmentsCharged: 1,
+		Amount:              500_000,
+		RemainingBalance:    500_000,
+		Carryover:           100_000,
+		Status:              model.DeductionActive,
+	}
+	transaction := &model.DeductionTransaction{DueAmount: 500_000, AppliedAmount: 500_000, CarryoverBefore: loan.Carryover}
+
+	loan.Apply(transaction)
+	assert.Equal(t, model.DeductionCompleted, loan.Status)
+
+	loan.Revert(transaction)
+	assert.Equal(t, model.DeductionActive, loan.Status)
+	assert.Equal(t, model.Money(500_000), loan.RemainingBalance)
+	assert.Equal(t, model.Money(100_000), loan.Carryover)
+	assert.Equal(t, 1, loan.InstallmentsCharged)
+}
@@ -0,0 +1,57 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"payroll/domain/model"
+	"payroll/usecase"
+)
+
+func TestEmploymentWindow(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	hired := time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)
+	left := time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC)
+
+	from, to, ok := (&model.User{HireDate: &hired}).EmploymentWindow(start, end)
+	assert.True(t, ok)
+	assert.Equal(t, hired, from)
+	assert.Equal(t, end, to)
+
+	_, _, ok = (&model.User{TerminationDate: &left}).EmploymentWindow(start, end)
+	assert.False(t, ok, "left before the period")
+
+	from, to, ok = (&model.User{}).EmploymentWindow(start, end)
+	assert.True(t, ok)
+	assert.Equal(t, start, from)
+	assert.Equal(t, end, to)
+}
+
+func TestBasePayComponentProratesPartialEmployment(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	hired := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+
+	pc := newPayContext()
+	pc.Period = &model.PayrollPeriod{StartDate: start, EndDate: end}
+	pc.ProrationPolicy = model.ProrationSalaryMinusAbsences
+	pc.WorkingDays = 21
+	pc.CalendarDays = 30
+	pc.SalarySegments = []usecase.SalarySegment{{StartDate: hired, EndDate: end, Salary: 6_300_000}}
+	for d := hired; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			pc.Attendances = append(pc.Attendances, model.Attendance{Date: d})
+		}
+	}
+
+	items, err := usecase.BasePayComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Base pay (2025-06-16 to 2025-06-30)", items[0].Label)
+	// 15 of 30 calendar days employed, no absences while employed.
+	assert.Equal(t, model.Money(3_150_000), items[0].Amount)
+}
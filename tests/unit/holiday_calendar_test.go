@@ -0,0 +1,47 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"payroll/domain/model"
+	"payroll/usecase"
+)
+
+func TestHolidayCalendarIsWorkingDay(t *testing.T) {
+	calendar := usecase.NewHolidayCalendar([]model.Holiday{
+		{Date: time.Date(2025, 8, 18, 0, 0, 0, 0, time.UTC), Name: "Independence Day (collective leave)", Type: model.HolidayCollectiveLeave},
+		{Date: time.Date(2025, 8, 17, 0, 0, 0, 0, time.UTC), Name: "Independence Day", Type: model.HolidayPublic},
+	})
+
+	assert.False(t, calendar.IsWorkingDay(time.Date(2025, 8, 16, 0, 0, 0, 0, time.UTC)), "saturday")
+	assert.False(t, calendar.IsWorkingDay(time.Date(2025, 8, 18, 0, 0, 0, 0, time.UTC)), "collective leave")
+	assert.True(t, calendar.IsWorkingDay(time.Date(2025, 8, 19, 0, 0, 0, 0, time.UTC)))
+
+	// Lookups ignore the time of day.
+	_, ok := calendar.Lookup(time.Date(2025, 8, 17, 13, 30, 0, 0, time.UTC))
+	assert.True(t, ok)
+}
+
+func TestOvertimeOnHolidays(t *testing.T) {
+	pc := newPayContext()
+	pc.User.Salary = 3_460_000
+	pc.Holidays = usecase.NewHolidayCalendar([]model.Holiday{
+		{Date: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), Name: "Labour Day", Type: model.HolidayPublic},
+		{Date: time.Date(2025, 5, 30, 0, 0, 0, 0, time.UTC), Name: "Collective leave", Type: model.HolidayCollectiveLeave},
+	})
+
+	assert.Equal(t, usecase.OvertimePublicHoliday, pc.ClassifyOvertimeDay(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, usecase.OvertimeRestDay, pc.ClassifyOvertimeDay(time.Date(2025, 5, 30, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, usecase.OvertimeWorkday, pc.ClassifyOvertimeDay(time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC)))
+
+	pc.Overtimes = []model.Overtime{{Date: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), Hours: 3}}
+	items, err := usecase.OvertimeComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Overtime (public holiday, hours 1-8, 2x)", items[0].Label)
+	assert.Equal(t, model.Money(120_000), items[0].Amount)
+}
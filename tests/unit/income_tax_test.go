@@ -0,0 +1,158 @@
+package unit
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"payroll/domain/model"
+	"payroll/usecase"
+)
+
+func loadTaxTable(t *testing.T) *model.TaxTable {
+	data, err := os.ReadFile("../../database/data/pph21_2024.json")
+	require.NoError(t, err)
+
+	var table model.TaxTable
+	require.NoError(t, json.Unmarshal(data, &table))
+	return &table
+}
+
+func newTaxContext(t *testing.T, month time.Month, gross model.Money) *usecase.PayContext {
+	pc := newPayContext()
+	pc.User.NPWP = "09.254.294.3-407.000"
+	pc.TaxTable = loadTaxTable(t)
+	pc.Period.EndDate = time.Date(2025, month, 28, 0, 0, 0, 0, time.UTC)
+	pc.Items = []model.PayslipItem{
+		{Code: model.PayCodeBasePay, Type: model.PayslipItemEarning, Amount: gross, Taxable: true},
+		{Code: model.PayCodeReimbursement, Type: model.PayslipItemEarning, Amount: 750_000},
+	}
+	return pc
+}
+
+func TestIncomeTaxMonthlyTER(t *testing.T) {
+	cases := []struct {
+		name       string
+		married    bool
+		dependents int
+		want       model.Money
+	}{
+		{"TK/0 uses category A", false, 0, 200_000},
+		{"K/1 uses category B", true, 1, 150_000},
+		{"K/3 uses category C", true, 3, 150_000},
+		{"dependents are capped at three", true, 5, 150_000},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pc := newTaxContext(t, time.March, 10_000_000)
+			pc.User.TaxMarried = tc.married
+			pc.User.TaxDependents = tc.dependents
+
+			items, err := usecase.IncomeTaxComponent{}.Calculate(pc)
+			require.NoError(t, err)
+			require.Len(t, items, 1)
+			assert.Equal(t, model.PayslipItemDeduction, items[0].Type)
+			assert.Equal(t, tc.want, items[0].Amount)
+		})
+	}
+}
+
+func TestIncomeTaxWithoutNPWP(t *testing.T) {
+	pc := newTaxContext(t, time.March, 10_000_000)
+	pc.User.NPWP = ""
+
+	items, err := usecase.IncomeTaxComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, model.Money(240_000), items[0].Amount)
+}
+
+func TestIncomeTaxBelowThreshold(t *testing.T) {
+	pc := newTaxContext(t, time.March, 5_000_000)
+
+	items, err := usecase.IncomeTaxComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestIncomeTaxDecemberReconciliation(t *testing.T) {
+	pc := newTaxContext(t, time.December, 10_000_000)
+	for i := 0; i < 11; i++ {
+		pc.PriorPayslips = append(pc.PriorPayslips, model.Payslip{TaxableIncome: 10_000_000, TaxAmount: 200_000})
+	}
+
+	// 120M gross - 6M occupational cost - 54M PTKP = 60M taxable, 5% = 3M.
+	items, err := usecase.IncomeTaxComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, model.PayslipItemDeduction, items[0].Type)
+	assert.Equal(t, model.Money(800_000), items[0].Amount)
+}
+
+func TestIncomeTaxDecemberRefund(t *testing.T) {
+	pc := newTaxContext(t, time.December, 10_000_000)
+	for i := 0; i < 11; i++ {
+		pc.PriorPayslips = append(pc.PriorPayslips, model.Payslip{TaxableIncome: 10_000_000, TaxAmount: 320_000})
+	}
+
+	items, err := usecase.IncomeTaxComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, model.PayslipItemEarning, items[0].Type)
+	assert.False(t, items[0].Taxable)
+	assert.Equal(t, model.Money(520_000), items[0].Amount)
+}
+
+func TestIncomeTaxSemiMonthly(t *testing.T) {
+	first := &model.PayrollPeriod{
+		StartDate: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	// The first half of the month withholds nothing
+	pc := newTaxContext(t, time.March, 5_000_000)
+	pc.PayFrequency = model.PaySemiMonthly
+	pc.Period = first
+	items, err := usecase.IncomeTaxComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	// The second half withholds TER on the month's 10M, as a monthly
+	// employee earning 10M would
+	pc = newTaxContext(t, time.March, 5_000_000)
+	pc.PayFrequency = model.PaySemiMonthly
+	pc.Period.StartDate = time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC)
+	pc.Period.EndDate = time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)
+	pc.PriorPayslips = []model.Payslip{{TaxableIncome: 5_000_000, PayrollPeriod: *first}}
+	items, err = usecase.IncomeTaxComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, model.Money(200_000), items[0].Amount)
+}
+
+func TestIncomeTaxSemiMonthlyDecember(t *testing.T) {
+	// The period ending December 15 does not reconcile the year
+	pc := newTaxContext(t, time.December, 5_000_000)
+	pc.PayFrequency = model.PaySemiMonthly
+	pc.Period.StartDate = time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)
+	pc.Period.EndDate = time.Date(2025, time.December, 15, 0, 0, 0, 0, time.UTC)
+	assert.False(t, pc.ClosesYear())
+	items, err := usecase.IncomeTaxComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	pc.Period.StartDate = time.Date(2025, time.December, 16, 0, 0, 0, 0, time.UTC)
+	pc.Period.EndDate = time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC)
+	assert.True(t, pc.ClosesYear())
+}
+
+func TestIncomeTaxRequiresTable(t *testing.T) {
+	pc := newPayContext()
+	_, err := usecase.IncomeTaxComponent{}.Calculate(pc)
+	assert.Error(t, err)
+}
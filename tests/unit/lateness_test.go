@@ -0,0 +1,101 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"payroll/domain/model"
+	"payroll/usecase"
+)
+
+func TestShiftPunctuality(t *testing.T) {
+	shift := model.Shift{StartTime: "09:00", EndTime: "17:00", LateGraceMinutes: 10, EarlyLeaveGraceMinutes: 5}
+	date := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 6, 2, hour, minute, 0, 0, time.UTC)
+	}
+
+	checkOut := at(17, 0)
+	late, early, err := shift.Punctuality(date, at(9, 10), &checkOut)
+	require.NoError(t, err)
+	assert.Zero(t, late, "within grace")
+	assert.Zero(t, early)
+
+	checkOut = at(16, 30)
+	late, early, err = shift.Punctuality(date, at(9, 25), &checkOut)
+	require.NoError(t, err)
+	assert.Equal(t, 25, late, "past grace the whole delay counts")
+	assert.Equal(t, 30, early)
+
+	late, early, err = shift.Punctuality(date, at(8, 45), nil)
+	require.NoError(t, err)
+	assert.Zero(t, late)
+	assert.Zero(t, early, "no check-out yet")
+}
+
+func TestShiftPunctualityOvernight(t *testing.T) {
+	shift := model.Shift{StartTime: "22:00", EndTime: "06:00"}
+	checkOut := time.Date(2025, 6, 3, 5, 20, 0, 0, time.UTC)
+
+	late, early, err := shift.Punctuality(time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 2, 22, 15, 0, 0, time.UTC), &checkOut)
+	require.NoError(t, err)
+	assert.Equal(t, 15, late)
+	assert.Equal(t, 40, early)
+}
+
+func newLatenessPayContext(policy model.LatenessPolicy, rate model.Money) *usecase.PayContext {
+	pc := newPayContext()
+	pc.User.Salary = 3_460_000
+	pc.User.Company = &model.Company{LatenessPolicy: policy, LatenessRate: rate}
+	pc.Attendances = []model.Attendance{
+		{LateMinutes: 20},
+		{LateMinutes: 10, EarlyLeaveMinutes: 30},
+		{},
+	}
+	pc.Items = []model.PayslipItem{{Code: model.PayCodeBasePay, Type: model.PayslipItemEarning, Amount: 3_460_000}}
+	return pc
+}
+
+func TestLatenessComponentPolicies(t *testing.T) {
+	summary := newLatenessPayContext(model.LatenessNone, 0).Punctuality()
+	assert.Equal(t, usecase.Punctuality{LateCount: 2, LateMinutes: 30, EarlyLeaveCount: 1, EarlyLeaveMinutes: 30}, summary)
+
+	items, err := usecase.LatenessComponent{}.Calculate(newLatenessPayContext(model.LatenessNone, 0))
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	items, err = usecase.LatenessComponent{}.Calculate(newLatenessPayContext(model.LatenessPerMinute, 1_000))
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Late arrival (2 times, 30 minutes)", items[0].Label)
+	assert.Equal(t, model.Money(30_000), items[0].Amount)
+	assert.Equal(t, model.Money(30_000), items[1].Amount)
+
+	items, err = usecase.LatenessComponent{}.Calculate(newLatenessPayContext(model.LatenessPerIncident, 25_000))
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, model.Money(50_000), items[0].Amount)
+	assert.Equal(t, model.Money(25_000), items[1].Amount)
+
+	// 3,460,000 / 173 = 20,000 an hour; 30 minutes is half of it.
+	items, err = usecase.LatenessComponent{}.Calculate(newLatenessPayContext(model.LatenessHourlyWage, 0))
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, model.Money(20_000), items[0].Rate)
+	assert.Equal(t, model.Money(10_000), items[0].Amount)
+	assert.Equal(t, model.PayslipItemDeduction, items[0].Type)
+}
+
+func TestLatenessComponentRespectsNetPayFloor(t *testing.T) {
+	pc := newLatenessPayContext(model.LatenessPerIncident, 25_000)
+	pc.NetPayFloor = 3_400_000
+
+	items, err := usecase.LatenessComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, model.Money(50_000), items[0].Amount)
+	assert.Equal(t, model.Money(10_000), items[1].Amount)
+}
@@ -0,0 +1,109 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"payroll/domain/model"
+	"payroll/usecase"
+)
+
+func TestPaidLeaveDays(t *testing.T) {
+	pc := newPayContext()
+	pc.Period = &model.PayrollPeriod{
+		StartDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC),
+	}
+	pc.Holidays = usecase.NewHolidayCalendar([]model.Holiday{
+		{Date: time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC), Name: "Eid al-Adha", Type: model.HolidayPublic},
+	})
+	pc.Leaves = []model.LeaveRequest{
+		// Wednesday 4 to Monday 9 June: the holiday and the weekend are not leave days.
+		{StartDate: time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC), LeaveType: model.LeaveType{Paid: true}},
+		{StartDate: time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 6, 17, 0, 0, 0, 0, time.UTC), LeaveType: model.LeaveType{Paid: false}},
+	}
+
+	assert.Equal(t, 3, pc.PaidLeaveDays(pc.Period.StartDate, pc.Period.EndDate))
+	assert.Equal(t, 2, pc.PaidLeaveDays(pc.Period.StartDate, time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestBasePayCountsPaidLeave(t *testing.T) {
+	pc := newPayContext()
+	pc.Period = &model.PayrollPeriod{
+		StartDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC),
+	}
+	pc.Attendances = make([]model.Attendance, 8)
+	pc.Leaves = []model.LeaveRequest{
+		{StartDate: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC), LeaveType: model.LeaveType{Paid: true}},
+		{StartDate: time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC), LeaveType: model.LeaveType{Paid: false}},
+	}
+
+	items, err := usecase.BasePayComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, 10.0, items[0].Quantity)
+	assert.Equal(t, model.Money(2_000_000), items[0].Amount)
+}
+
+func TestAccruedLeaveDays(t *testing.T) {
+	annual := &model.LeaveType{AccrualDaysPerYear: 12, ServiceMonthsRequired: 12}
+	hired := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	user := &model.User{HireDate: &hired}
+
+	assert.Zero(t, usecase.AccruedLeaveDays(annual, user, 2024, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)), "first year of service")
+	// Eligible from 15 March 2025: March is the first full month counted.
+	assert.Equal(t, 4.0, usecase.AccruedLeaveDays(annual, user, 2025, time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 3.0, usecase.AccruedLeaveDays(annual, user, 2025, time.Date(2025, 6, 29, 0, 0, 0, 0, time.UTC)), "june not over")
+	assert.Equal(t, 12.0, usecase.AccruedLeaveDays(annual, user, 2026, time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)))
+
+	terminated := time.Date(2026, 5, 20, 0, 0, 0, 0, time.UTC)
+	user.TerminationDate = &terminated
+	assert.Equal(t, 4.0, usecase.AccruedLeaveDays(annual, user, 2026, time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)))
+
+	fourteen := &model.LeaveType{AccrualDaysPerYear: 14}
+	assert.Equal(t, 1.17, usecase.AccruedLeaveDays(fourteen, user, 2026, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestLeaveBalanceYearEnd(t *testing.T) {
+	balance := model.LeaveBalance{Accrued: 12, Used: 3}
+	carryOver := balance.CloseYear(6)
+	assert.Equal(t, 6.0, carryOver)
+	assert.Equal(t, 3.0, balance.Expired)
+	assert.Zero(t, balance.Available())
+
+	expiresAt := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	next := model.LeaveBalance{Accrued: 3, CarriedOver: carryOver, CarryOverExpiresAt: &expiresAt, Used: 2}
+	next.LapseCarryOver(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC))
+	assert.False(t, next.CarryOverLapsed)
+
+	next.LapseCarryOver(expiresAt)
+	assert.True(t, next.CarryOverLapsed)
+	assert.Equal(t, 4.0, next.Expired, "the 2 days taken came out of the carried over days")
+	assert.Equal(t, 3.0, next.Available())
+
+	next.LapseCarryOver(expiresAt.AddDate(0, 1, 0))
+	assert.Equal(t, 4.0, next.Expired, "lapses once")
+}
+
+func TestLeavePayoutComponent(t *testing.T) {
+	pc := newPayContext()
+	pc.LeaveBalances = []model.LeaveBalance{
+		{BaseModel: model.BaseModel{ID: 7}, Accrued: 5, CarriedOver: 1, Used: 0.5, LeaveType: model.LeaveType{Name: "Annual leave"}},
+		{Accrued: 2, Used: 2},
+	}
+
+	items, err := usecase.LeavePayoutComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Leave payout (Annual leave)", items[0].Label)
+	assert.Equal(t, 5.5, items[0].Quantity)
+	assert.Equal(t, model.Money(200_000), items[0].Rate)
+	assert.Equal(t, model.Money(1_100_000), items[0].Amount)
+	assert.True(t, items[0].Taxable)
+	require.NotNil(t, items[0].LeaveBalanceID)
+	assert.Equal(t, uint(7), *items[0].LeaveBalanceID)
+}
@@ -0,0 +1,48 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"payroll/domain/model"
+)
+
+func TestMoneyMulDivRoundsHalfAwayFromZero(t *testing.T) {
+	cases := []struct {
+		name     string
+		amount   model.Money
+		num, den int64
+		want     model.Money
+	}{
+		{"exact", 4_400_000, 1, 22, 200_000},
+		{"below half", 10, 1, 3, 3},
+		{"half rounds up", 5, 1, 2, 3},
+		{"above half", 5, 2, 3, 3},
+		{"negative half rounds down", -5, 1, 2, -3},
+		{"negative divisor", 5, 1, -2, -3},
+		{"large product", 9_000_000_000_000, 1_000_000, 3_000_000, 3_000_000_000_000},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.amount.MulDiv(tc.num, tc.den))
+		})
+	}
+}
+
+func TestMoneyPercent(t *testing.T) {
+	assert.Equal(t, model.Money(40_000), model.Money(4_000_000).Percent(100))
+	assert.Equal(t, model.Money(12_345), model.Money(4_938_000).Percent(25))
+}
+
+func TestMoneyMulHours(t *testing.T) {
+	assert.Equal(t, model.Money(25_000), model.Money(10_000).MulHours(2.5))
+	assert.Equal(t, model.Money(3_333), model.Money(10_000).MulHours(1.0/3))
+}
+
+func TestMoneyString(t *testing.T) {
+	assert.Equal(t, "IDR 0", model.Money(0).String())
+	assert.Equal(t, "IDR 1.250.000", model.Money(1_250_000).String())
+	assert.Equal(t, "-IDR 999", model.Money(-999).String())
+}
@@ -0,0 +1,117 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"payroll/domain/model"
+	"payroll/usecase"
+)
+
+func newPayContext() *usecase.PayContext {
+	return &usecase.PayContext{
+		User:   &model.User{Salary: 4_400_000},
+		Period: &model.PayrollPeriod{},
+	}
+}
+
+func TestBasePayComponent(t *testing.T) {
+	pc := newPayContext()
+	pc.Attendances = make([]model.Attendance, 10)
+
+	items, err := usecase.BasePayComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, model.PayCodeBasePay, items[0].Code)
+	assert.Equal(t, model.PayslipItemEarning, items[0].Type)
+	assert.Equal(t, model.Money(2_000_000), items[0].Amount)
+}
+
+func TestBasePayComponentRoundsOnce(t *testing.T) {
+	pc := newPayContext()
+	pc.User.Salary = 5_000_000
+	pc.Attendances = make([]model.Attendance, 21)
+
+	items, err := usecase.BasePayComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	// 5,000,000 * 21 / 22 = 4,772,727.27...
+	assert.Equal(t, model.Money(4_772_727), items[0].Amount)
+	assert.Equal(t, model.Money(227_273), items[0].Rate)
+}
+
+func TestOvertimeComponent(t *testing.T) {
+	pc := newPayContext()
+	pc.User.Salary = 3_460_000 // hourly base 20,000
+
+	items, err := usecase.OvertimeComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	assert.Empty(t, items, "no overtime should produce no line")
+
+	monday := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	pc.Overtimes = []model.Overtime{
+		{Date: monday, Hours: 2},
+		{Date: monday.AddDate(0, 0, 1), Hours: 0.5},
+	}
+	items, err = usecase.OvertimeComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, "Overtime (workday, hour 1, 1.5x)", items[0].Label)
+	assert.Equal(t, 1.5, items[0].Quantity)
+	assert.Equal(t, model.Money(30_000), items[0].Rate)
+	assert.Equal(t, model.Money(45_000), items[0].Amount)
+
+	assert.Equal(t, "Overtime (workday, hour 2+, 2x)", items[1].Label)
+	assert.Equal(t, 1.0, items[1].Quantity)
+	assert.Equal(t, model.Money(40_000), items[1].Amount)
+}
+
+func TestOvertimeComponentRestDayLadder(t *testing.T) {
+	pc := newPayContext()
+	pc.User.Salary = 3_460_000
+
+	saturday := time.Date(2025, 6, 7, 0, 0, 0, 0, time.UTC)
+	pc.Overtimes = []model.Overtime{{Date: saturday, Hours: 10}}
+
+	items, err := usecase.OvertimeComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	assert.Equal(t, "Overtime (rest day, hours 1-8, 2x)", items[0].Label)
+	assert.Equal(t, model.Money(320_000), items[0].Amount)
+	assert.Equal(t, "Overtime (rest day, hour 9, 3x)", items[1].Label)
+	assert.Equal(t, model.Money(60_000), items[1].Amount)
+	assert.Equal(t, "Overtime (rest day, hour 10+, 4x)", items[2].Label)
+	assert.Equal(t, model.Money(80_000), items[2].Amount)
+}
+
+func TestReimbursementComponent(t *testing.T) {
+	pc := newPayContext()
+	pc.Reimbursements = []model.Reimbursement{
+		{Amount: 150_000, Description: "Taxi"},
+		{Amount: 50_000, Description: "Parking"},
+	}
+
+	items, err := usecase.ReimbursementComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Taxi", items[0].Label)
+	assert.Equal(t, "Parking", items[1].Label)
+}
+
+func TestPayContextTotals(t *testing.T) {
+	pc := newPayContext()
+	pc.Items = []model.PayslipItem{
+		{Code: model.PayCodeBasePay, Type: model.PayslipItemEarning, Amount: 1_000_000},
+		{Code: model.PayCodeOvertime, Type: model.PayslipItemEarning, Amount: 200_000},
+		{Code: "LOAN", Type: model.PayslipItemDeduction, Amount: 300_000},
+	}
+
+	assert.Equal(t, model.Money(1_200_000), pc.Total(model.PayslipItemEarning))
+	assert.Equal(t, model.Money(300_000), pc.Total(model.PayslipItemDeduction))
+	assert.Equal(t, model.Money(200_000), pc.SumByCode(model.PayCodeOvertime))
+	assert.Equal(t, model.Money(900_000), pc.NetPay())
+}
@@ -0,0 +1,108 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"payroll/domain/model"
+	"payroll/usecase"
+)
+
+func periodDates(periods []model.PayrollPeriod) []string {
+	dates := make([]string, 0, len(periods))
+	for _, period := range periods {
+		dates = append(dates, period.StartDate.Format("2006-01-02")+".."+period.EndDate.Format("2006-01-02"))
+	}
+	return dates
+}
+
+func TestGeneratePeriods(t *testing.T) {
+	from := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)
+	// A Monday; the first February period starts a whole number of weeks later.
+	anchor := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		frequency model.PayFrequency
+		want      []string
+	}{
+		{model.PayMonthly, []string{"2024-02-01..2024-02-29"}},
+		{model.PaySemiMonthly, []string{"2024-02-01..2024-02-15", "2024-02-16..2024-02-29"}},
+		{model.PayBiWeekly, []string{"2024-02-12..2024-02-25", "2024-02-26..2024-03-10"}},
+		{model.PayWeekly, []string{"2024-02-05..2024-02-11", "2024-02-12..2024-02-18", "2024-02-19..2024-02-25", "2024-02-26..2024-03-03"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.frequency), func(t *testing.T) {
+			group := &model.PayGroup{Frequency: tc.frequency, AnchorDate: anchor}
+			group.ID = 7
+
+			periods := usecase.GeneratePeriods(group, from, to)
+			assert.Equal(t, tc.want, periodDates(periods))
+			for _, period := range periods {
+				require.NotNil(t, period.PayGroupID)
+				assert.Equal(t, uint(7), *period.PayGroupID)
+			}
+		})
+	}
+}
+
+func TestGeneratePeriodsBeforeAnchor(t *testing.T) {
+	group := &model.PayGroup{
+		Frequency:  model.PayWeekly,
+		AnchorDate: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+	}
+
+	periods := usecase.GeneratePeriods(group,
+		time.Date(2024, time.February, 20, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, []string{"2024-02-26..2024-03-03"}, periodDates(periods),
+		"weeks before the anchor stay aligned to it")
+}
+
+func TestClosesMonth(t *testing.T) {
+	pc := newPayContext()
+	pc.PayFrequency = model.PayWeekly
+
+	pc.Period.StartDate = time.Date(2024, time.February, 19, 0, 0, 0, 0, time.UTC)
+	pc.Period.EndDate = time.Date(2024, time.February, 25, 0, 0, 0, 0, time.UTC)
+	assert.False(t, pc.ClosesMonth())
+
+	pc.Period.StartDate = time.Date(2024, time.February, 26, 0, 0, 0, 0, time.UTC)
+	pc.Period.EndDate = time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC)
+	assert.True(t, pc.ClosesMonth())
+
+	pc.PayFrequency = model.PayMonthly
+	pc.Period.EndDate = time.Date(2024, time.February, 15, 0, 0, 0, 0, time.UTC)
+	assert.True(t, pc.ClosesMonth(), "monthly periods always close the month")
+}
+
+func TestWeeklyPeriodPaysShareOfMonthlyItems(t *testing.T) {
+	pc := newPayContext()
+	pc.User.Salary = 5_200_000
+	pc.PayFrequency = model.PayWeekly
+	pc.ProrationPolicy = model.ProrationWorkingDays
+	pc.WorkingDays = 5
+	pc.Attendances = make([]model.Attendance, 5)
+	pc.Period.StartDate = time.Date(2024, time.February, 12, 0, 0, 0, 0, time.UTC)
+	pc.Period.EndDate = time.Date(2024, time.February, 18, 0, 0, 0, 0, time.UTC)
+	pc.Allowances = []model.AllowanceAssignment{{
+		Allowance: model.Allowance{Name: "Transport", Basis: model.AllowanceFixed, Amount: 500_000},
+	}}
+
+	items, err := usecase.BasePayComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, model.Money(1_200_000), items[0].Amount, "a week is a 52nd of the yearly salary")
+
+	items, err = usecase.AllowanceComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	assert.Empty(t, items, "fixed allowances wait for the period closing the month")
+
+	items, err = usecase.BPJSComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
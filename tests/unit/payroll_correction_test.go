@@ -0,0 +1,63 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"payroll/domain/model"
+	"payroll/usecase"
+)
+
+func TestAdjustmentPayslip(t *testing.T) {
+	periodID := uint(3)
+	issued := []model.Payslip{{
+		BaseModel:       model.BaseModel{ID: 7},
+		UserID:          1,
+		PayrollPeriodID: 4,
+		Kind:            model.PayslipRegular,
+		Items: []model.PayslipItem{
+			{Code: model.PayCodeBasePay, Label: "Base pay", Type: model.PayslipItemEarning, Amount: 5_000_000, Taxable: true},
+			{Code: model.PayCodeRetro, Label: "Retro pay", Type: model.PayslipItemEarning, Amount: 500_000, Taxable: true, RetroPeriodID: &periodID},
+			{Code: model.PayCodeDeduction, Label: "Loan", Type: model.PayslipItemDeduction, Amount: 250_000},
+		},
+		Records: []model.PayslipRecord{{RecordType: model.PayslipRecordAttendance, RecordID: 11}},
+	}}
+
+	// Nothing changed: the retro and deduction lines the correction does not
+	// calculate are not taken back
+	unchanged := &model.Payslip{
+		UserID:          1,
+		PayrollPeriodID: 4,
+		Items: []model.PayslipItem{
+			{Code: model.PayCodeBasePay, Label: "Base pay", Type: model.PayslipItemEarning, Amount: 5_000_000, Taxable: true},
+		},
+		Records: []model.PayslipRecord{{RecordType: model.PayslipRecordAttendance, RecordID: 11}},
+	}
+	assert.Nil(t, usecase.AdjustmentPayslip(unchanged, issued))
+
+	// A missed reimbursement adds its line and record only
+	missed := &model.Payslip{
+		UserID:          1,
+		PayrollPeriodID: 4,
+		Items: []model.PayslipItem{
+			{Code: model.PayCodeBasePay, Label: "Base pay", Type: model.PayslipItemEarning, Amount: 5_000_000, Taxable: true},
+			{Code: model.PayCodeReimbursement, Label: "Reimbursement", Type: model.PayslipItemEarning, Amount: 150_000},
+		},
+		Records: []model.PayslipRecord{
+			{RecordType: model.PayslipRecordAttendance, RecordID: 11},
+			{RecordType: model.PayslipRecordReimbursement, RecordID: 21},
+		},
+	}
+	adjustment := usecase.AdjustmentPayslip(missed, issued)
+	require.NotNil(t, adjustment)
+	assert.Equal(t, model.PayslipAdjustment, adjustment.Kind)
+	require.NotNil(t, adjustment.OriginalPayslipID)
+	assert.Equal(t, uint(7), *adjustment.OriginalPayslipID)
+	require.Len(t, adjustment.Items, 1)
+	assert.Equal(t, model.PayCodeReimbursement, adjustment.Items[0].Code)
+	assert.Equal(t, model.Money(150_000), adjustment.TotalPay)
+	require.Len(t, adjustment.Records, 1)
+	assert.Equal(t, uint(21), adjustment.Records[0].RecordID)
+}
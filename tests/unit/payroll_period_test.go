@@ -0,0 +1,45 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"payroll/domain/model"
+)
+
+func TestPeriodStatusTransitions(t *testing.T) {
+	assert.True(t, model.PeriodDraft.CanTransitionTo(model.PeriodOpen))
+	assert.True(t, model.PeriodOpen.CanTransitionTo(model.PeriodLocked))
+	assert.True(t, model.PeriodLocked.CanTransitionTo(model.PeriodProcessed))
+	assert.True(t, model.PeriodProcessed.CanTransitionTo(model.PeriodOpen), "reversal reopens the period")
+	assert.True(t, model.PeriodPaid.CanTransitionTo(model.PeriodClosed))
+
+	assert.False(t, model.PeriodDraft.CanTransitionTo(model.PeriodProcessed), "drafts are opened before payroll runs")
+	assert.False(t, model.PeriodPaid.CanTransitionTo(model.PeriodOpen))
+	assert.False(t, model.PeriodClosed.CanTransitionTo(model.PeriodOpen))
+	assert.False(t, model.PeriodStatus("archived").IsValid())
+}
+
+func TestPayrollPeriodSetStatus(t *testing.T) {
+	period := &model.PayrollPeriod{Status: model.PeriodLocked}
+	now := time.Date(2024, time.March, 28, 10, 0, 0, 0, time.UTC)
+
+	period.SetStatus(model.PeriodProcessed, now)
+	assert.True(t, period.IsProcessed)
+	assert.False(t, period.IsPaid)
+	assert.Equal(t, &now, period.ProcessedAt)
+
+	period.SetStatus(model.PeriodPaid, now.Add(time.Hour))
+	assert.True(t, period.IsProcessed)
+	assert.True(t, period.IsPaid)
+	assert.NotNil(t, period.ProcessedAt, "paying keeps the processing time")
+	assert.NotNil(t, period.PaidAt)
+
+	period = &model.PayrollPeriod{Status: model.PeriodProcessed}
+	period.SetStatus(model.PeriodProcessed, now)
+	period.SetStatus(model.PeriodOpen, now)
+	assert.False(t, period.IsProcessed)
+	assert.Nil(t, period.ProcessedAt)
+}
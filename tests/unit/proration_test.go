@@ -0,0 +1,63 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"payroll/domain/model"
+	"payroll/usecase"
+)
+
+func TestProrateBasePay(t *testing.T) {
+	const salary = model.Money(6_600_000)
+	basis := usecase.ProrationBasis{
+		PaidDays:     20,
+		WorkingDays:  22,
+		CalendarDays: 30,
+		Divisor:      22,
+	}
+
+	cases := []struct {
+		policy     model.ProrationPolicy
+		wantAmount model.Money
+		wantRate   model.Money
+	}{
+		{model.ProrationFixedDivisor, 6_000_000, 300_000},
+		{model.ProrationWorkingDays, 6_000_000, 300_000},
+		{model.ProrationCalendarDays, 6_160_000, 220_000},
+		{model.ProrationSalaryMinusAbsences, 6_000_000, 300_000},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			amount, rate := usecase.ProrateBasePay(tc.policy, salary, basis)
+			assert.Equal(t, tc.wantAmount, amount)
+			assert.Equal(t, tc.wantRate, rate)
+		})
+	}
+}
+
+func TestProrateBasePayShortPeriod(t *testing.T) {
+	const salary = model.Money(6_600_000)
+	// A 21 working day month where the employee attended every day.
+	basis := usecase.ProrationBasis{PaidDays: 21, WorkingDays: 21, CalendarDays: 28, Divisor: 22}
+
+	amount, _ := usecase.ProrateBasePay(model.ProrationFixedDivisor, salary, basis)
+	assert.Equal(t, model.Money(6_300_000), amount, "fixed divisor underpays a short month")
+
+	amount, _ = usecase.ProrateBasePay(model.ProrationWorkingDays, salary, basis)
+	assert.Equal(t, salary, amount)
+
+	amount, _ = usecase.ProrateBasePay(model.ProrationCalendarDays, salary, basis)
+	assert.Equal(t, salary, amount)
+
+	amount, _ = usecase.ProrateBasePay(model.ProrationSalaryMinusAbsences, salary, basis)
+	assert.Equal(t, salary, amount)
+}
+
+func TestProrateBasePayNeverNegative(t *testing.T) {
+	basis := usecase.ProrationBasis{PaidDays: 0, WorkingDays: 23, CalendarDays: 31, Divisor: 22}
+	amount, _ := usecase.ProrateBasePay(model.ProrationSalaryMinusAbsences, 4_400_000, basis)
+	assert.Equal(t, model.Money(0), amount)
+}
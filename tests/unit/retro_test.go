@@ -0,0 +1,62 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"payroll/domain/model"
+	"payroll/usecase"
+)
+
+func TestRetroPayComponent(t *testing.T) {
+	may := &model.PayrollPeriod{
+		BaseModel: model.BaseModel{ID: 5},
+		StartDate: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC),
+	}
+	retroContext := &usecase.PayContext{
+		User:            &model.User{Salary: 4_400_000},
+		Period:          may,
+		ProrationPolicy: model.ProrationFixedDivisor,
+		Attendances:     make([]model.Attendance, 22),
+		SalarySegments: []usecase.SalarySegment{
+			{StartDate: may.StartDate, EndDate: may.EndDate, Salary: 5_500_000},
+		},
+	}
+
+	pc := newPayContext()
+	pc.RetroPeriods = []usecase.RetroPeriod{{
+		Context: retroContext,
+		PaidSegments: []usecase.SalarySegment{
+			{StartDate: may.StartDate, EndDate: may.EndDate, Salary: 4_400_000},
+		},
+	}}
+
+	items, err := usecase.RetroPayComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, model.PayCodeRetro, items[0].Code)
+	assert.Equal(t, "Retro pay (2025-05-01 to 2025-05-31)", items[0].Label)
+	assert.Equal(t, model.Money(1_100_000), items[0].Amount)
+	assert.True(t, items[0].Taxable)
+	require.NotNil(t, items[0].RetroPeriodID)
+	assert.Equal(t, uint(5), *items[0].RetroPeriodID)
+
+	// Once the difference is paid nothing is owed.
+	pc.RetroPeriods[0].Paid = 1_100_000
+	items, err = usecase.RetroPayComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	// Inputs other than the salary do not give retro pay: both sides are
+	// priced with the same attendance.
+	pc.RetroPeriods[0].Paid = 0
+	pc.RetroPeriods[0].PaidSegments = retroContext.SalarySegments
+	retroContext.Attendances = make([]model.Attendance, 10)
+	items, err = usecase.RetroPayComponent{}.Calculate(pc)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
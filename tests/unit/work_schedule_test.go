@@ -0,0 +1,90 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"payroll/domain/model"
+	"payroll/usecase"
+)
+
+func TestShiftExpectedHours(t *testing.T) {
+	day := model.Shift{StartTime: "08:00", EndTime: "17:00", BreakMinutes: 60}
+	assert.Equal(t, 8.0, day.ExpectedHours())
+
+	night := model.Shift{StartTime: "22:00", EndTime: "06:00", BreakMinutes: 30}
+	assert.Equal(t, 7.5, night.ExpectedHours())
+
+	start, end, err := night.Times(time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 2, 22, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2025, 6, 3, 6, 0, 0, 0, time.UTC), end)
+}
+
+func TestEmployeeScheduleRotation(t *testing.T) {
+	morning := model.Shift{Code: "M", StartTime: "06:00", EndTime: "14:00"}
+	night := model.Shift{Code: "N", StartTime: "22:00", EndTime: "06:00"}
+
+	// Two mornings, two nights, two rest days, from Sunday 1 June 2025.
+	rotation := model.WorkSchedule{
+		CycleDays:  6,
+		AnchorDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		Days: []model.WorkScheduleDay{
+			{DayIndex: 0, Shift: morning},
+			{DayIndex: 1, Shift: morning},
+			{DayIndex: 2, Shift: night},
+			{DayIndex: 3, Shift: night},
+		},
+	}
+	schedule := usecase.NewEmployeeSchedule([]model.ScheduleAssignment{
+		{EffectiveDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), WorkSchedule: rotation},
+	})
+
+	assert.Equal(t, "M", schedule.ShiftOn(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)).Code, "sunday")
+	assert.Equal(t, "N", schedule.ShiftOn(time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC)).Code)
+	assert.Nil(t, schedule.ShiftOn(time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC)), "rest day")
+	assert.Equal(t, "M", schedule.ShiftOn(time.Date(2025, 6, 7, 0, 0, 0, 0, time.UTC)).Code, "next cycle")
+
+	// Before the assignment the default Monday to Friday schedule applies.
+	assert.Nil(t, schedule.ShiftOn(time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC)), "saturday")
+	assert.Equal(t, usecase.DefaultShift.Code, schedule.ShiftOn(time.Date(2025, 5, 30, 0, 0, 0, 0, time.UTC)).Code)
+
+	holidays := usecase.NewHolidayCalendar([]model.Holiday{
+		{Date: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Name: "Pancasila Day", Type: model.HolidayPublic},
+	})
+	assert.False(t, schedule.IsWorkingDay(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), holidays))
+	assert.True(t, schedule.IsWorkingDay(time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), holidays))
+}
+
+func TestDefaultScheduleIsWeekdays(t *testing.T) {
+	var schedule *usecase.EmployeeSchedule
+
+	assert.Nil(t, schedule.ShiftOn(time.Date(2025, 6, 7, 0, 0, 0, 0, time.UTC)), "saturday")
+	shift := schedule.ShiftOn(time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC))
+	require.NotNil(t, shift)
+	assert.Equal(t, 8.0, shift.ExpectedHours())
+}
+
+func TestRestDayOvertimeFollowsSchedule(t *testing.T) {
+	pc := newPayContext()
+	pc.Schedule = usecase.NewEmployeeSchedule([]model.ScheduleAssignment{{
+		EffectiveDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		WorkSchedule: model.WorkSchedule{
+			CycleDays:  7,
+			AnchorDate: time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), // a Monday
+			Days: []model.WorkScheduleDay{
+				{DayIndex: 2, Shift: usecase.DefaultShift},
+				{DayIndex: 3, Shift: usecase.DefaultShift},
+				{DayIndex: 4, Shift: usecase.DefaultShift},
+				{DayIndex: 5, Shift: usecase.DefaultShift},
+				{DayIndex: 6, Shift: usecase.DefaultShift},
+			},
+		},
+	}})
+
+	assert.Equal(t, usecase.OvertimeRestDay, pc.ClassifyOvertimeDay(time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)), "monday off")
+	assert.Equal(t, usecase.OvertimeWorkday, pc.ClassifyOvertimeDay(time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC)), "sunday shift")
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"payroll/domain/model"
+)
+
+// AllowanceComponent pays the allowances assigned to the employee, one line
+// per allowance. Per-day allowances count the attendance and overtime
+// records the run loaded for the period.
+type AllowanceComponent struct{}
+
+func (AllowanceComponent) Code() string {
+	return model.PayCodeAllowance
+}
+
+func (AllowanceComponent) Calculate(pc *PayContext) ([]model.PayslipItem, error) {
+	overtimeDays := make(map[string]bool)
+	for _, overtime := range pc.Overtimes {
+		overtimeDays[dateKey(overtime.Date)] = true
+	}
+
+	var items []model.PayslipItem
+	for _, assignment := range pc.Allowances {
+		var quantity int
+		switch assignment.Allowance.Basis {
+		case model.AllowanceFixed:
+			if pc.ClosesMonth() {
+				quantity = 1
+			}
+		case model.AllowancePerAttendanceDay:
+			quantity = len(pc.Attendances)
+		case model.AllowancePerOvertimeDay:
+			quantity = len(overtimeDays)
+		}
+		if quantity == 0 {
+			continue
+		}
+
+		rate := assignment.EffectiveAmount()
+		items = append(items, model.PayslipItem{
+			Code:     model.PayCodeAllowance,
+			Label:    assignment.Allowance.Name,
+			Type:     model.PayslipItemEarning,
+			Quantity: float64(quantity),
+			Rate:     rate,
+			Amount:   rate * model.Money(quantity),
+			Taxable:  assignment.Allowance.Taxable,
+		})
+	}
+	return items, nil
+}
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"payroll/domain/dto"
+	"payroll/domain/model"
+	"payroll/repositories"
+)
+
+func NewAllowanceUsecase(allowanceRepo repositories.AllowanceRepository, userRepo repositories.UserRepository, auditRepo repositories.AuditRepository) *AllowanceUsecase {
+	return &AllowanceUsecase{
+		allowanceRepo: allowanceRepo,
+		userRepo:      userRepo,
+		auditRepo:     auditRepo,
+	}
+}
+
+func (a *AllowanceUsecase) GetAllowances() ([]model.Allowance, error) {
+	return a.allowanceRepo.GetAll()
+}
+
+func (a *AllowanceUsecase) CreateAllowance(req *dto.AllowanceRequest, userID uint, ipAddress, requestID string) (*model.Allowance, error) {
+	allowance := &model.Allowance{
+		BaseModel: model.BaseModel{
+			CreatedBy: &userID,
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		Taxable:  true,
+		IsActive: true,
+	}
+	if err := applyAllowanceRequest(allowance, req); err != nil {
+		return nil, err
+	}
+
+	if err := a.allowanceRepo.Create(allowance); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(allowance)
+	a.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "CREATE",
+		TableName: "allowances",
+		RecordID:  &allowance.ID,
+		NewData:   string(newData),
+	})
+
+	return allowance, nil
+}
+
+func (a *AllowanceUsecase) UpdateAllowance(id uint, req *dto.AllowanceRequest, userID uint, ipAddress, requestID string) (*model.Allowance, error) {
+	allowance, err := a.allowanceRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("allowance not found")
+	}
+	oldData, _ := json.Marshal(allowance)
+
+	if err := applyAllowanceRequest(allowance, req); err != nil {
+		return nil, err
+	}
+	allowance.UpdatedBy = &userID
+	allowance.IPAddress = ipAddress
+	allowance.RequestID = requestID
+
+	if err := a.allowanceRepo.Update(allowance); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(allowance)
+	a.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "UPDATE",
+		TableName: "allowances",
+		RecordID:  &allowance.ID,
+		OldData:   string(oldData),
+		NewData:   string(newData),
+	})
+
+	return allowance, nil
+}
+
+func (a *AllowanceUsecase) AssignAllowance(allowanceID uint, req *dto.AllowanceAssignmentRequest, userID uint, ipAddress, requestID string) (*model.AllowanceAssignment, error) {
+	allowance, err := a.allowanceRepo.GetByID(allowanceID)
+	if err != nil {
+		return nil, errors.New("allowance not found")
+	}
+
+	if (req.UserID == nil) == (req.Grade == "") {
+		return nil, errors.New("assign the allowance to either an employee or a grade")
+	}
+	if req.UserID != nil {
+		employee, err := a.userRepo.GetByID(*req.UserID)
+		if err != nil || employee.Role != "employee" {
+			return nil, errors.New("employee not found")
+		}
+	}
+
+	assignment := &model.AllowanceAssignment{
+		BaseModel: model.BaseModel{
+			CreatedBy: &userID,
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		AllowanceID: allowance.ID,
+		UserID:      req.UserID,
+		Grade:       req.Grade,
+		Amount:      req.Amount,
+	}
+
+	if err := a.allowanceRepo.CreateAssignment(assignment); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(assignment)
+	a.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "CREATE",
+		TableName: "allowance_assignments",
+		RecordID:  &assignment.ID,
+		NewData:   string(newData),
+	})
+
+	return assignment, nil
+}
+
+func (a *AllowanceUsecase) RemoveAssignment(id uint, userID uint, ipAddress, requestID string) error {
+	assignment, err := a.allowanceRepo.GetAssignmentByID(id)
+	if err != nil {
+		return errors.New("allowance assignment not found")
+	}
+	oldData, _ := json.Marshal(assignment)
+
+	if err := a.allowanceRepo.DeleteAssignment(assignment); err != nil {
+		return err
+	}
+
+	// Log audit
+	a.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "DELETE",
+		TableName: "allowance_assignments",
+		RecordID:  &assignment.ID,
+		OldData:   string(oldData),
+	})
+
+	return nil
+}
+
+func applyAllowanceRequest(allowance *model.Allowance, req *dto.AllowanceRequest) error {
+	basis := model.AllowanceBasis(req.Basis)
+	if !basis.IsValid() {
+		return errors.New("invalid allowance basis")
+	}
+	if req.Amount <= 0 {
+		return errors.New("amount must be greater than zero")
+	}
+
+	allowance.Code = req.Code
+	allowance.Name = req.Name
+	allowance.Basis = basis
+	allowance.Amount = req.Amount
+	if req.Taxable != nil {
+		allowance.Taxable = *req.Taxable
+	}
+	if req.IsActive != nil {
+		allowance.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+// resolveAllowances keeps one assignment per allowance, preferring the one
+// made to the employee over the one made to their grade.
+func resolveAllowances(assignments []model.AllowanceAssignment) []model.AllowanceAssignment {
+	index := make(map[uint]int)
+	resolved := make([]model.AllowanceAssignment, 0, len(assignments))
+	for _, assignment := range assignments {
+		i, ok := index[assignment.AllowanceID]
+		if !ok {
+			index[assignment.AllowanceID] = len(resolved)
+			resolved = append(resolved, assignment)
+			continue
+		}
+		if assignment.UserID != nil && resolved[i].UserID == nil {
+			resolved[i] = assignment
+		}
+	}
+	return resolved
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"payroll/domain/dto"
 	"payroll/repositories"
 	"time"
@@ -10,27 +11,63 @@ import (
 	"payroll/domain/model"
 )
 
-func NewAttendanceUsecase(attendanceRepo repositories.AttendanceRepository, auditRepo repositories.AuditRepository) *AttendanceUsecase {
+func NewAttendanceUsecase(attendanceRepo repositories.AttendanceRepository, holidayRepo repositories.HolidayRepository, scheduleRepo repositories.ScheduleRepository, leaveRepo repositories.LeaveRepository, userRepo repositories.UserRepository, payrollRepo repositories.PayrollRepository, auditRepo repositories.AuditRepository) *AttendanceUsecase {
 	return &AttendanceUsecase{
 		attendanceRepo: attendanceRepo,
+		holidayRepo:    holidayRepo,
+		scheduleRepo:   scheduleRepo,
+		leaveRepo:      leaveRepo,
+		userRepo:       userRepo,
+		payrollRepo:    payrollRepo,
 		auditRepo:      auditRepo,
 	}
 }
 
 func (a *AttendanceUsecase) SubmitAttendance(userID uint, req *dto.AttendanceRequest, ipAddress, requestID string) error {
+	return a.createAttendance(userID, req, false, userID, ipAddress, requestID)
+}
+
+// RecordAttendance enters attendance for an employee on an administrator's
+// behalf, also in processed periods so a correction run can pay it.
+func (a *AttendanceUsecase) RecordAttendance(employeeID uint, req *dto.AttendanceRequest, userID uint, ipAddress, requestID string) error {
+	return a.createAttendance(employeeID, req, true, userID, ipAddress, requestID)
+}
+
+// createAttendance stores the attendance of employeeID entered by userID.
+func (a *AttendanceUsecase) createAttendance(employeeID uint, req *dto.AttendanceRequest, correction bool, userID uint, ipAddress, requestID string) error {
 	// Parse date
 	date, err := time.Parse("2006-01-02", req.Date)
 	if err != nil {
 		return errors.New("invalid date format")
 	}
 
-	// Check if weekend
-	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
-		return errors.New("cannot submit attendance on weekends")
+	// Check the date is still open for submissions
+	if err := checkSubmissionDate(a.userRepo, a.payrollRepo, employeeID, date, correction); err != nil {
+		return err
+	}
+
+	// Check if a shift is scheduled
+	assignments, err := a.scheduleRepo.GetAssignmentsByUser(employeeID)
+	if err != nil {
+		return err
+	}
+	shift := NewEmployeeSchedule(assignments).ShiftOn(date)
+	if shift == nil {
+		return errors.New("cannot submit attendance on a rest day")
+	}
+
+	// Check if public holiday or collective leave
+	if holiday, _ := a.holidayRepo.GetByDate(date); holiday != nil {
+		return fmt.Errorf("cannot submit attendance on a holiday (%s)", holiday.Name)
+	}
+
+	// Check if on leave
+	if leaves, _ := a.leaveRepo.GetOverlapping(employeeID, date, date); len(leaves) > 0 {
+		return fmt.Errorf("cannot submit attendance during leave (%s)", leaves[0].LeaveType.Name)
 	}
 
 	// Check if already submitted for this date
-	existing, _ := a.attendanceRepo.GetByUserAndDate(userID, date)
+	existing, _ := a.attendanceRepo.GetByUserAndDate(employeeID, date)
 	if existing != nil {
 		return errors.New("attendance already submitted for this date")
 	}
@@ -49,24 +86,41 @@ func (a *AttendanceUsecase) SubmitAttendance(userID uint, req *dto.AttendanceReq
 		if err != nil {
 			return errors.New("invalid check-out time format")
 		}
+		if !checkOutTime.After(checkIn) {
+			checkOutTime = checkOutTime.AddDate(0, 0, 1) // overnight shift
+		}
 		checkOut = &checkOutTime
 		workingHours = checkOut.Sub(checkIn).Hours()
-		if workingHours > 8 {
-			workingHours = 8 // Cap at 8 hours for regular work
+		if expected := shift.ExpectedHours(); workingHours > expected {
+			workingHours = expected // Cap at the shift's hours for regular work
 		}
 	}
 
+	lateMinutes, earlyLeaveMinutes, err := shift.Punctuality(date, checkIn, checkOut)
+	if err != nil {
+		return err
+	}
+
+	var shiftID *uint
+	if shift.ID != 0 {
+		shiftID = &shift.ID
+	}
+
 	attendance := &model.Attendance{
 		BaseModel: model.BaseModel{
 			CreatedBy: &userID,
 			IPAddress: ipAddress,
 			RequestID: requestID,
 		},
-		UserID:       userID,
+		UserID:       employeeID,
 		Date:         date,
 		CheckIn:      checkIn,
 		CheckOut:     checkOut,
 		WorkingHours: workingHours,
+		ShiftID:      shiftID,
+
+		LateMinutes:       lateMinutes,
+		EarlyLeaveMinutes: earlyLeaveMinutes,
 	}
 
 	if err := a.attendanceRepo.Create(attendance); err != nil {
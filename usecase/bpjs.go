@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"payroll/domain/model"
+)
+
+// BPJSRates are the social security contribution rates in basis points and
+// the wages they are capped at.
+type BPJSRates struct {
+	HealthWageCap  model.Money
+	HealthEmployee int64
+	HealthEmployer int64
+
+	JHTEmployee int64
+	JHTEmployer int64
+
+	PensionWageCap  model.Money
+	PensionEmployee int64
+	PensionEmployer int64
+
+	JKM int64
+	JKK map[model.JKKRiskClass]int64
+}
+
+// DefaultBPJSRates returns the rates in force for 2025. The pension (JP)
+// wage cap is adjusted by BPJS Ketenagakerjaan every March.
+func DefaultBPJSRates() BPJSRates {
+	return BPJSRates{
+		HealthWageCap:  12_000_000,
+		HealthEmployee: 100,
+		HealthEmployer: 400,
+
+		JHTEmployee: 200,
+		JHTEmployer: 370,
+
+		PensionWageCap:  10_547_400,
+		PensionEmployee: 100,
+		PensionEmployer: 200,
+
+		JKM: 30,
+		JKK: map[model.JKKRiskClass]int64{
+			model.JKKRiskVeryLow:  24,
+			model.JKKRiskLow:      54,
+			model.JKKRiskMedium:   89,
+			model.JKKRiskHigh:     127,
+			model.JKKRiskVeryHigh: 174,
+		},
+	}
+}
+
+// BPJSComponent adds BPJS Kesehatan and Ketenagakerjaan contributions.
+// Employee shares are deductions; JHT and JP employee shares reduce taxable
+// income. Employer shares are employer cost lines, and the ones the tax
+// rules treat as a benefit in kind (health, JKK, JKM) are taxable income.
+// Contributions are monthly, so only the period closing the month takes them.
+type BPJSComponent struct {
+	Rates BPJSRates
+}
+
+func (BPJSComponent) Code() string {
+	return "BPJS"
+}
+
+func (c BPJSComponent) Calculate(pc *PayContext) ([]model.PayslipItem, error) {
+	rates := c.Rates
+	if rates.JKK == nil {
+		rates = DefaultBPJSRates()
+	}
+
+	wage := pc.MonthlyWage()
+	if wage <= 0 || !pc.ClosesMonth() {
+		return nil, nil
+	}
+	healthWage := wage.Min(rates.HealthWageCap)
+	pensionWage := wage.Min(rates.PensionWageCap)
+
+	riskClass := model.JKKRiskVeryLow
+	if pc.User.Company != nil && pc.User.Company.JKKRiskClass.IsValid() {
+		riskClass = pc.User.Company.JKKRiskClass
+	}
+
+	lines := []struct {
+		code          string
+		label         string
+		itemType      model.PayslipItemType
+		base          model.Money
+		basisPoints   int64
+		taxable       bool
+		taxDeductible bool
+	}{
+		{model.PayCodeBPJSHealthEmployee, "BPJS Kesehatan", model.PayslipItemDeduction, healthWage, rates.HealthEmployee, false, false},
+		{model.PayCodeBPJSJHTEmployee, "BPJS JHT", model.PayslipItemDeduction, wage, rates.JHTEmployee, false, true},
+		{model.PayCodeBPJSPensionEmployee, "BPJS JP", model.PayslipItemDeduction, pensionWage, rates.PensionEmployee, false, true},
+		{model.PayCodeBPJSHealthEmployer, "BPJS Kesehatan (employer)", model.PayslipItemEmployerCost, healthWage, rates.HealthEmployer, true, false},
+		{model.PayCodeBPJSJHTEmployer, "BPJS JHT (employer)", model.PayslipItemEmployerCost, wage, rates.JHTEmployer, false, false},
+		{model.PayCodeBPJSPensionEmployer, "BPJS JP (employer)", model.PayslipItemEmployerCost, pensionWage, rates.PensionEmployer, false, false},
+		{model.PayCodeBPJSJKK, "BPJS JKK (" + string(riskClass) + " risk)", model.PayslipItemEmployerCost, wage, rates.JKK[riskClass], true, false},
+		{model.PayCodeBPJSJKM, "BPJS JKM", model.PayslipItemEmployerCost, wage, rates.JKM, true, false},
+	}
+
+	items := make([]model.PayslipItem, 0, len(lines))
+	for _, line := range lines {
+		amount := line.base.Percent(line.basisPoints)
+		if amount == 0 {
+			continue
+		}
+		items = append(items, model.PayslipItem{
+			Code:          line.code,
+			Label:         line.label,
+			Type:          line.itemType,
+			Quantity:      float64(line.basisPoints) / 10_000, // share of the wage in Rate
+			Rate:          line.base,
+			Amount:        amount,
+			Taxable:       line.taxable,
+			TaxDeductible: line.taxDeductible,
+		})
+	}
+	return items, nil
+}
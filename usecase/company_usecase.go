@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"payroll/domain/dto"
+	"payroll/domain/model"
+	"payroll/repositories"
+)
+
+func NewCompanyUsecase(companyRepo repositories.CompanyRepository, auditRepo repositories.AuditRepository) *CompanyUsecase {
+	return &CompanyUsecase{
+		companyRepo: companyRepo,
+		auditRepo:   auditRepo,
+	}
+}
+
+func (c *CompanyUsecase) CreateCompany(req *dto.CompanyRequest, userID uint, ipAddress, requestID string) (*model.Company, error) {
+	company := &model.Company{
+		BaseModel: model.BaseModel{
+			CreatedBy: &userID,
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+	}
+	if err := applyCompanyRequest(company, req); err != nil {
+		return nil, err
+	}
+
+	if err := c.companyRepo.Create(company); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(company)
+	c.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "CREATE",
+		TableName: "companies",
+		RecordID:  &company.ID,
+		NewData:   string(newData),
+	})
+
+	return company, nil
+}
+
+func (c *CompanyUsecase) UpdateCompany(id uint, req *dto.CompanyRequest, userID uint, ipAddress, requestID string) (*model.Company, error) {
+	company, err := c.companyRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("company not found")
+	}
+	oldData, _ := json.Marshal(company)
+
+	if err := applyCompanyRequest(company, req); err != nil {
+		return nil, err
+	}
+	company.UpdatedBy = &userID
+	company.IPAddress = ipAddress
+	company.RequestID = requestID
+
+	if err := c.companyRepo.Update(company); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(company)
+	c.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "UPDATE",
+		TableName: "companies",
+		RecordID:  &company.ID,
+		OldData:   string(oldData),
+		NewData:   string(newData),
+	})
+
+	return company, nil
+}
+
+func (c *CompanyUsecase) GetCompanies() ([]model.Company, error) {
+	return c.companyRepo.GetAll()
+}
+
+func applyCompanyRequest(company *model.Company, req *dto.CompanyRequest) error {
+	policy := model.ProrationPolicy(req.ProrationPolicy)
+	if policy == "" {
+		policy = model.ProrationFixedDivisor
+	}
+	if !policy.IsValid() {
+		return errors.New("invalid proration policy")
+	}
+
+	divisor := req.ProrationDivisor
+	if divisor == 0 {
+		divisor = model.DefaultProrationDivisor
+	}
+
+	riskClass := model.JKKRiskClass(req.JKKRiskClass)
+	if riskClass == "" {
+		riskClass = model.JKKRiskVeryLow
+	}
+	if !riskClass.IsValid() {
+		return errors.New("invalid JKK risk class")
+	}
+
+	latenessPolicy := model.LatenessPolicy(req.LatenessPolicy)
+	if latenessPolicy == "" {
+		latenessPolicy = model.LatenessNone
+	}
+	if !latenessPolicy.IsValid() {
+		return errors.New("invalid lateness policy")
+	}
+	if (latenessPolicy == model.LatenessPerMinute || latenessPolicy == model.LatenessPerIncident) && req.LatenessRate <= 0 {
+		return errors.New("lateness rate is required for this lateness policy")
+	}
+
+	company.Name = req.Name
+	company.ProrationPolicy = policy
+	company.ProrationDivisor = divisor
+	company.JKKRiskClass = riskClass
+	company.NetPayFloor = req.NetPayFloor
+	company.LatenessPolicy = latenessPolicy
+	company.LatenessRate = req.LatenessRate
+	company.SubmissionFutureDays = req.SubmissionFutureDays
+	return nil
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"fmt"
+	"payroll/domain/model"
+	"time"
+)
+
+// SalarySegment is a stretch of a payroll period paid at one salary.
+type SalarySegment struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Salary    model.Money
+}
+
+// SalarySegments splits the period into the stretches each salary of the
+// history was in effect. history must be ordered by effective date. Days
+// before the first entry, or the whole period without any history, are paid
+// at fallback.
+func SalarySegments(history []model.Compensation, fallback model.Money, startDate, endDate time.Time) []SalarySegment {
+	salary := fallback
+	next := 0
+	for next < len(history) && !dateKeyAfter(history[next].EffectiveDate, startDate) {
+		salary = history[next].Salary
+		next++
+	}
+
+	var segments []SalarySegment
+	segmentStart := startDate
+	for ; next < len(history) && !dateKeyAfter(history[next].EffectiveDate, endDate); next++ {
+		change := history[next]
+		changeDate := time.Date(change.EffectiveDate.Year(), change.EffectiveDate.Month(), change.EffectiveDate.Day(),
+			0, 0, 0, 0, startDate.Location())
+		if change.Salary == salary {
+			continue
+		}
+		segments = append(segments, SalarySegment{StartDate: segmentStart, EndDate: changeDate.AddDate(0, 0, -1), Salary: salary})
+		segmentStart = changeDate
+		salary = change.Salary
+	}
+	return append(segments, SalarySegment{StartDate: segmentStart, EndDate: endDate, Salary: salary})
+}
+
+// employedSalarySegments returns the salary segments of the days in
+// [startDate, endDate] the user was employed. Days before hire or after
+// termination are not covered, so base pay is prorated for them. Callers
+// only price users employed during the period.
+func employedSalarySegments(user *model.User, history []model.Compensation, startDate, endDate time.Time) []SalarySegment {
+	from, to, ok := user.EmploymentWindow(startDate, endDate)
+	if !ok {
+		return nil
+	}
+	return SalarySegments(history, user.Salary, from, to)
+}
+
+// payslipSalarySegments turns segments into the rows stored with a payslip.
+func payslipSalarySegments(segments []SalarySegment) []model.PayslipSalarySegment {
+	rows := make([]model.PayslipSalarySegment, 0, len(segments))
+	for _, segment := range segments {
+		rows = append(rows, model.PayslipSalarySegment{
+			StartDate: segment.StartDate,
+			EndDate:   segment.EndDate,
+			Salary:    segment.Salary,
+		})
+	}
+	return rows
+}
+
+// paidSalarySegments returns the salary segments payslip was paid with.
+// Payslips from before segments were stored paid BaseSalary for the whole
+// period when they have a single base pay line; for the others ok is false.
+func paidSalarySegments(user *model.User, payslip *model.Payslip) (segments []SalarySegment, ok bool) {
+	if len(payslip.SalarySegments) > 0 {
+		for _, row := range payslip.SalarySegments {
+			segments = append(segments, SalarySegment{StartDate: row.StartDate, EndDate: row.EndDate, Salary: row.Salary})
+		}
+		return segments, true
+	}
+
+	basePayLines := 0
+	for _, item := range payslip.Items {
+		if item.Code == model.PayCodeBasePay {
+			basePayLines++
+		}
+	}
+	if basePayLines != 1 {
+		return nil, false
+	}
+	from, to, employed := user.EmploymentWindow(payslip.PayrollPeriod.StartDate, payslip.PayrollPeriod.EndDate)
+	if !employed {
+		return nil, false
+	}
+	return []SalarySegment{{StartDate: from, EndDate: to, Salary: payslip.BaseSalary}}, true
+}
+
+// sameSalarySegments reports whether a and b pay the same salaries over the
+// same days.
+func sameSalarySegments(a, b []SalarySegment) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if dateKey(a[i].StartDate) != dateKey(b[i].StartDate) || dateKey(a[i].EndDate) != dateKey(b[i].EndDate) || a[i].Salary != b[i].Salary {
+			return false
+		}
+	}
+	return true
+}
+
+// dateKeyAfter reports whether a falls on a later calendar day than b.
+func dateKeyAfter(a, b time.Time) bool {
+	return dateKey(a) > dateKey(b)
+}
+
+func (pc *PayContext) coversPeriod(segment SalarySegment) bool {
+	return dateKey(segment.StartDate) == dateKey(pc.Period.StartDate) && dateKey(segment.EndDate) == dateKey(pc.Period.EndDate)
+}
+
+// segmentBasis counts the days of segment the way ProrationBasis does for a
+// whole period.
+func (pc *PayContext) segmentBasis(segment SalarySegment) ProrationBasis {
+	basis := ProrationBasis{Divisor: pc.ProrationDivisor}
+	for d := segment.StartDate; !d.After(segment.EndDate); d = d.AddDate(0, 0, 1) {
+		basis.CalendarDays++
+		if pc.Schedule.IsWorkingDay(d, pc.Holidays) {
+			basis.WorkingDays++
+		}
+	}
+	for _, attendance := range pc.Attendances {
+		key := dateKey(attendance.Date)
+		if key >= dateKey(segment.StartDate) && key <= dateKey(segment.EndDate) {
+			basis.PaidDays++
+		}
+	}
+	basis.PaidDays += pc.PaidLeaveDays(segment.StartDate, segment.EndDate)
+	return basis
+}
+
+func salarySegmentLabel(segment SalarySegment) string {
+	return fmt.Sprintf("Base pay (%s to %s)", segment.StartDate.Format("2006-01-02"), segment.EndDate.Format("2006-01-02"))
+}
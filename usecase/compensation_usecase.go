@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"payroll/domain/dto"
+	"payroll/domain/model"
+	"payroll/repositories"
+	"time"
+)
+
+func NewCompensationUsecase(compensationRepo repositories.CompensationRepository, userRepo repositories.UserRepository, auditRepo repositories.AuditRepository) *CompensationUsecase {
+	return &CompensationUsecase{
+		compensationRepo: compensationRepo,
+		userRepo:         userRepo,
+		auditRepo:        auditRepo,
+	}
+}
+
+func (c *CompensationUsecase) GetCompensationHistory(employeeID uint) ([]model.Compensation, error) {
+	return c.compensationRepo.GetByUser(employeeID)
+}
+
+func (c *CompensationUsecase) CreateCompensation(req *dto.CompensationRequest, userID uint, ipAddress, requestID string) (*model.Compensation, error) {
+	employee, err := c.userRepo.GetByID(req.UserID)
+	if err != nil || employee.Role != "employee" {
+		return nil, errors.New("employee not found")
+	}
+
+	effectiveDate, err := time.Parse("2006-01-02", req.EffectiveDate)
+	if err != nil {
+		return nil, errors.New("invalid effective date format")
+	}
+
+	existing, _ := c.compensationRepo.GetByUserAndDate(employee.ID, effectiveDate)
+	if existing != nil {
+		return nil, errors.New("a salary change already takes effect on this date")
+	}
+
+	// Start the history from the salary the employee is paid now, so the
+	// change does not reach back to the days before it
+	history, err := c.compensationRepo.GetByUser(employee.ID)
+	if err != nil {
+		return nil, err
+	}
+	if start := startingSalaryDate(employee); len(history) == 0 && start.Before(effectiveDate) {
+		starting := &model.Compensation{
+			BaseModel: model.BaseModel{
+				// Dated when the salary took effect: it records what was
+				// already paid, so it gives no earlier period retro pay
+				CreatedAt: start,
+				CreatedBy: &userID,
+				IPAddress: ipAddress,
+				RequestID: requestID,
+			},
+			UserID:        employee.ID,
+			Salary:        employee.Salary,
+			EffectiveDate: start,
+			Reason:        "Starting salary",
+		}
+		if err := c.compensationRepo.Create(starting); err != nil {
+			return nil, err
+		}
+	}
+
+	compensation := &model.Compensation{
+		BaseModel: model.BaseModel{
+			CreatedBy: &userID,
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:        employee.ID,
+		Salary:        req.Salary,
+		EffectiveDate: effectiveDate,
+		Reason:        req.Reason,
+	}
+
+	if err := c.compensationRepo.Create(compensation); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(compensation)
+	c.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "CREATE",
+		TableName: "compensations",
+		RecordID:  &compensation.ID,
+		NewData:   string(newData),
+	})
+
+	return compensation, nil
+}
+
+// startingSalaryDate is when employee's current salary took effect for a
+// history started from it: their hire date, or the day they were created.
+func startingSalaryDate(employee *model.User) time.Time {
+	if employee.HireDate != nil {
+		return *employee.HireDate
+	}
+	created := employee.CreatedAt
+	return time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// DeleteCompensation cancels a scheduled salary change. Changes already in
+// effect are history and cannot be removed.
+func (c *CompensationUsecase) DeleteCompensation(id uint, userID uint, ipAddress, requestID string) error {
+	compensation, err := c.compensationRepo.GetByID(id)
+	if err != nil {
+		return errors.New("salary change not found")
+	}
+
+	if !compensation.EffectiveDate.After(time.Now()) {
+		return errors.New("salary change is already in effect")
+	}
+	oldData, _ := json.Marshal(compensation)
+
+	if err := c.compensationRepo.Delete(compensation); err != nil {
+		return err
+	}
+
+	// Log audit
+	c.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "DELETE",
+		TableName: "compensations",
+		RecordID:  &compensation.ID,
+		OldData:   string(oldData),
+	})
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"payroll/domain/model"
+)
+
+// DeductionComponent applies the employee's one-off, recurring and loan
+// deductions after tax. Deductions are taken oldest first and never bring
+// net pay below the floor; whatever does not fit is recorded as a shortfall
+// and carried to the next period.
+type DeductionComponent struct{}
+
+func (DeductionComponent) Code() string {
+	return model.PayCodeDeduction
+}
+
+func (DeductionComponent) Calculate(pc *PayContext) ([]model.PayslipItem, error) {
+	available := (pc.NetPay() - pc.NetPayFloor).Max(0)
+
+	var items []model.PayslipItem
+	for _, deduction := range pc.Deductions {
+		due := deduction.AmountDue()
+		if due <= 0 {
+			continue
+		}
+
+		applied := due.Min(available)
+		available -= applied
+		pc.DeductionTransactions = append(pc.DeductionTransactions, model.DeductionTransaction{
+			DeductionID:     deduction.ID,
+			PayrollPeriodID: pc.Period.ID,
+			DueAmount:       due,
+			AppliedAmount:   applied,
+			Shortfall:       due - applied,
+			CarryoverBefore: deduction.Carryover,
+		})
+
+		if applied == 0 {
+			continue
+		}
+		items = append(items, model.PayslipItem{
+			Code:     model.PayCodeDeduction,
+			Label:    deduction.Description,
+			Type:     model.PayslipItemDeduction,
+			Quantity: 1,
+			Rate:     applied,
+			Amount:   applied,
+		})
+	}
+	return items, nil
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"payroll/domain/dto"
+	"payroll/domain/model"
+	"payroll/repositories"
+	"time"
+)
+
+func NewDeductionUsecase(deductionRepo repositories.DeductionRepository, userRepo repositories.UserRepository, auditRepo repositories.AuditRepository) *DeductionUsecase {
+	return &DeductionUsecase{
+		deductionRepo: deductionRepo,
+		userRepo:      userRepo,
+		auditRepo:     auditRepo,
+	}
+}
+
+func (d *DeductionUsecase) CreateDeduction(req *dto.DeductionRequest, userID uint, ipAddress, requestID string) (*model.Deduction, error) {
+	employee, err := d.userRepo.GetByID(req.UserID)
+	if err != nil || employee.Role != "employee" {
+		return nil, errors.New("employee not found")
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, errors.New("invalid start date format")
+	}
+
+	deduction := &model.Deduction{
+		BaseModel: model.BaseModel{
+			CreatedBy: &userID,
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:      employee.ID,
+		Type:        model.DeductionType(req.Type),
+		Description: req.Description,
+		StartDate:   startDate,
+		Status:      model.DeductionActive,
+	}
+
+	switch deduction.Type {
+	case model.DeductionOneOff:
+		if req.Amount <= 0 {
+			return nil, errors.New("amount must be greater than zero")
+		}
+		deduction.Amount = req.Amount
+		deduction.RemainingBalance = req.Amount
+	case model.DeductionRecurring:
+		if req.Amount <= 0 {
+			return nil, errors.New("amount must be greater than zero")
+		}
+		deduction.Amount = req.Amount
+		deduction.InstallmentCount = req.InstallmentCount
+	case model.DeductionLoan:
+		if req.Principal <= 0 {
+			return nil, errors.New("principal must be greater than zero")
+		}
+		if req.InstallmentCount <= 0 {
+			return nil, errors.New("installment count must be greater than zero")
+		}
+		deduction.Principal = req.Principal
+		deduction.InstallmentCount = req.InstallmentCount
+		// The last installment absorbs the rounding difference.
+		deduction.Amount = req.Principal.MulDiv(1, int64(req.InstallmentCount))
+		deduction.RemainingBalance = req.Principal
+	default:
+		return nil, errors.New("invalid deduction type")
+	}
+
+	if err := d.deductionRepo.Create(deduction); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(deduction)
+	d.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "CREATE",
+		TableName: "deductions",
+		RecordID:  &deduction.ID,
+		NewData:   string(newData),
+	})
+
+	return deduction, nil
+}
+
+func (d *DeductionUsecase) GetDeductions(employeeID uint) ([]model.Deduction, error) {
+	return d.deductionRepo.GetByUser(employeeID)
+}
+
+// CancelDeduction stops an active deduction; amounts already deducted stay
+// on the payslips that took them.
+func (d *DeductionUsecase) CancelDeduction(id uint, userID uint, ipAddress, requestID string) (*model.Deduction, error) {
+	deduction, err := d.deductionRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("deduction not found")
+	}
+	if deduction.Status != model.DeductionActive {
+		return nil, errors.New("deduction is not active")
+	}
+	oldData, _ := json.Marshal(deduction)
+
+	deduction.Status = model.DeductionCancelled
+	deduction.UpdatedBy = &userID
+	deduction.IPAddress = ipAddress
+	deduction.RequestID = requestID
+
+	if err := d.deductionRepo.Update(deduction); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(deduction)
+	d.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "CANCEL",
+		TableName: "deductions",
+		RecordID:  &deduction.ID,
+		OldData:   string(oldData),
+		NewData:   string(newData),
+	})
+
+	return deduction, nil
+}
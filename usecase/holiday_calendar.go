@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"payroll/domain/model"
+	"time"
+)
+
+// HolidayCalendar indexes holidays by calendar date for quick lookups while
+// counting working days and classifying overtime.
+type HolidayCalendar map[string]model.Holiday
+
+func NewHolidayCalendar(holidays []model.Holiday) HolidayCalendar {
+	calendar := make(HolidayCalendar, len(holidays))
+	for _, holiday := range holidays {
+		calendar[dateKey(holiday.Date)] = holiday
+	}
+	return calendar
+}
+
+func (c HolidayCalendar) Lookup(date time.Time) (model.Holiday, bool) {
+	holiday, ok := c[dateKey(date)]
+	return holiday, ok
+}
+
+// IsWorkingDay reports whether date is a working day on the default Monday
+// to Friday schedule: a weekday that is neither a public holiday nor a
+// collective leave day.
+func (c HolidayCalendar) IsWorkingDay(date time.Time) bool {
+	var defaultSchedule *EmployeeSchedule
+	return defaultSchedule.IsWorkingDay(date, c)
+}
+
+func dateKey(date time.Time) string {
+	return date.Format("2006-01-02")
+}
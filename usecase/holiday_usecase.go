@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"payroll/domain/dto"
+	"payroll/domain/model"
+	"payroll/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func NewHolidayUsecase(holidayRepo repositories.HolidayRepository, auditRepo repositories.AuditRepository, transactor repositories.Transactor) *HolidayUsecase {
+	return &HolidayUsecase{
+		holidayRepo: holidayRepo,
+		auditRepo:   auditRepo,
+		transactor:  transactor,
+	}
+}
+
+func (h *HolidayUsecase) GetHolidays(year int) ([]model.Holiday, error) {
+	startDate := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	return h.holidayRepo.GetByRange(startDate, endDate)
+}
+
+func (h *HolidayUsecase) CreateHoliday(req *dto.HolidayRequest, userID uint, ipAddress, requestID string) (*model.Holiday, error) {
+	date, holidayType, err := parseHolidayRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, _ := h.holidayRepo.GetByDate(date)
+	if existing != nil {
+		return nil, errors.New("a holiday already exists on this date")
+	}
+
+	holiday := &model.Holiday{
+		BaseModel: model.BaseModel{
+			CreatedBy: &userID,
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		Date: date,
+		Name: req.Name,
+		Type: holidayType,
+	}
+
+	if err := h.holidayRepo.Create(holiday); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(holiday)
+	h.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "CREATE",
+		TableName: "holidays",
+		RecordID:  &holiday.ID,
+		NewData:   string(newData),
+	})
+
+	return holiday, nil
+}
+
+func (h *HolidayUsecase) UpdateHoliday(id uint, req *dto.HolidayRequest, userID uint, ipAddress, requestID string) (*model.Holiday, error) {
+	holiday, err := h.holidayRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("holiday not found")
+	}
+
+	date, holidayType, err := parseHolidayRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, _ := h.holidayRepo.GetByDate(date)
+	if existing != nil && existing.ID != holiday.ID {
+		return nil, errors.New("a holiday already exists on this date")
+	}
+
+	oldData, _ := json.Marshal(holiday)
+	holiday.Date = date
+	holiday.Name = req.Name
+	holiday.Type = holidayType
+	holiday.UpdatedBy = &userID
+	holiday.IPAddress = ipAddress
+	holiday.RequestID = requestID
+
+	if err := h.holidayRepo.Update(holiday); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(holiday)
+	h.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "UPDATE",
+		TableName: "holidays",
+		RecordID:  &holiday.ID,
+		OldData:   string(oldData),
+		NewData:   string(newData),
+	})
+
+	return holiday, nil
+}
+
+func (h *HolidayUsecase) DeleteHoliday(id uint, userID uint, ipAddress, requestID string) error {
+	holiday, err := h.holidayRepo.GetByID(id)
+	if err != nil {
+		return errors.New("holiday not found")
+	}
+
+	if err := h.holidayRepo.Delete(holiday); err != nil {
+		return err
+	}
+
+	// Log audit
+	oldData, _ := json.Marshal(holiday)
+	h.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "DELETE",
+		TableName: "holidays",
+		RecordID:  &holiday.ID,
+		OldData:   string(oldData),
+	})
+
+	return nil
+}
+
+// ImportHolidays loads a yearly holiday list. Every entry is validated
+// before anything is written, and the entries are written in one
+// transaction; dates that already have a holiday are updated in place.
+func (h *HolidayUsecase) ImportHolidays(req *dto.HolidayImportRequest, userID uint, ipAddress, requestID string) ([]model.Holiday, error) {
+	type entry struct {
+		date        time.Time
+		holidayType model.HolidayType
+		name        string
+	}
+
+	entries := make([]entry, 0, len(req.Holidays))
+	seen := make(map[string]bool, len(req.Holidays))
+	for i := range req.Holidays {
+		date, holidayType, err := parseHolidayRequest(&req.Holidays[i])
+		if err != nil {
+			return nil, fmt.Errorf("holiday %d: %w", i+1, err)
+		}
+		if date.Year() != req.Year {
+			return nil, fmt.Errorf("holiday %d: %s is outside %d", i+1, req.Holidays[i].Date, req.Year)
+		}
+		if seen[dateKey(date)] {
+			return nil, fmt.Errorf("holiday %d: %s is listed more than once", i+1, req.Holidays[i].Date)
+		}
+		seen[dateKey(date)] = true
+		entries = append(entries, entry{date: date, holidayType: holidayType, name: req.Holidays[i].Name})
+	}
+
+	holidays := make([]model.Holiday, 0, len(entries))
+	err := h.transactor.WithinTransaction(func(repos *repositories.Repositories) error {
+		for _, e := range entries {
+			holiday, err := repos.Holiday.GetByDate(e.date)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if holiday == nil {
+				holiday = &model.Holiday{
+					BaseModel: model.BaseModel{
+						CreatedBy: &userID,
+					},
+					Date: e.date,
+				}
+			} else {
+				holiday.UpdatedBy = &userID
+			}
+			holiday.Name = e.name
+			holiday.Type = e.holidayType
+			holiday.IPAddress = ipAddress
+			holiday.RequestID = requestID
+
+			if holiday.ID == 0 {
+				err = repos.Holiday.Create(holiday)
+			} else {
+				err = repos.Holiday.Update(holiday)
+			}
+			if err != nil {
+				return err
+			}
+			holidays = append(holidays, *holiday)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(holidays)
+	h.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "IMPORT",
+		TableName: "holidays",
+		NewData:   string(newData),
+	})
+
+	return holidays, nil
+}
+
+func parseHolidayRequest(req *dto.HolidayRequest) (time.Time, model.HolidayType, error) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return time.Time{}, "", errors.New("invalid date format")
+	}
+
+	holidayType := model.HolidayType(req.Type)
+	if holidayType == "" {
+		holidayType = model.HolidayPublic
+	}
+	if !holidayType.IsValid() {
+		return time.Time{}, "", errors.New("invalid holiday type")
+	}
+
+	return date, holidayType, nil
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"payroll/domain/model"
+)
+
+// Statutory parameters of the PPh 21 calculation that are not part of the
+// yearly rate tables.
+const (
+	occupationalCostBasisPoints = 500       // biaya jabatan, 5% of gross
+	occupationalCostAnnualCap   = 6_000_000 // capped at Rp6,000,000 a year
+	noNPWPSurchargePercent      = 120       // 20% higher without an NPWP
+)
+
+// IncomeTaxComponent withholds PPh 21. Every month except December applies
+// the TER rate of the employee's category to the taxable gross of the month.
+// December recalculates the tax on the whole year with the progressive
+// brackets and withholds the difference with what was already withheld,
+// refunding any overpayment. Groups paid more than once a month withhold in
+// the period closing the month, on the month's total, and reconcile in the
+// period closing the year.
+type IncomeTaxComponent struct{}
+
+func (IncomeTaxComponent) Code() string {
+	return model.PayCodeIncomeTax
+}
+
+func (IncomeTaxComponent) Calculate(pc *PayContext) ([]model.PayslipItem, error) {
+	if pc.TaxTable == nil {
+		return nil, errors.New("no income tax table loaded")
+	}
+
+	status := pc.User.PTKPStatus()
+	ptkp, ok := findPTKP(pc.TaxTable, status)
+	if !ok {
+		return nil, fmt.Errorf("no PTKP defined for tax status %s", status)
+	}
+
+	if pc.ClosesYear() {
+		return annualIncomeTax(pc, ptkp)
+	}
+	if !pc.ClosesMonth() {
+		return nil, nil
+	}
+
+	// The month's taxable gross; earlier periods of the month withheld
+	// nothing unless they were corrected
+	gross := pc.TaxableIncome()
+	var withheld model.Money
+	if !paidMonthly(pc.PayFrequency) {
+		for _, payslip := range pc.PriorPayslips {
+			if sameMonth(payslip.PayrollPeriod.StartDate, pc.Period.StartDate) {
+				gross += payslip.TaxableIncome
+				withheld += payslip.TaxAmount
+			}
+		}
+	}
+
+	rate, ok := findTERRate(pc.TaxTable, ptkp.TERCategory, gross)
+	if !ok {
+		return nil, fmt.Errorf("no TER rate for category %s", ptkp.TERCategory)
+	}
+
+	tax := gross.Percent(rate)
+	if !pc.User.HasNPWP() {
+		tax = tax.MulDiv(noNPWPSurchargePercent, 100)
+	}
+	tax -= withheld
+	if tax <= 0 {
+		return nil, nil
+	}
+
+	return []model.PayslipItem{{
+		Code:     model.PayCodeIncomeTax,
+		Label:    fmt.Sprintf("PPh 21 (TER %s, %s)", ptkp.TERCategory, status),
+		Type:     model.PayslipItemDeduction,
+		Quantity: 1,
+		Rate:     tax,
+		Amount:   tax,
+	}}, nil
+}
+
+func annualIncomeTax(pc *PayContext, ptkp model.TaxPTKP) ([]model.PayslipItem, error) {
+	annualGross := pc.TaxableIncome()
+	annualDeductible := pc.TaxDeductible()
+	var withheld model.Money
+	for _, payslip := range pc.PriorPayslips {
+		annualGross += payslip.TaxableIncome
+		annualDeductible += payslip.TaxDeductible
+		withheld += payslip.TaxAmount
+	}
+
+	occupationalCost := annualGross.Percent(occupationalCostBasisPoints).Min(occupationalCostAnnualCap)
+	netIncome := annualGross - occupationalCost - annualDeductible
+	// Taxable income (PKP) is rounded down to whole thousands.
+	taxableIncome := (netIncome - ptkp.Amount).Max(0) / 1000 * 1000
+
+	annualTax := progressiveTax(pc.TaxTable.Brackets, taxableIncome)
+	if !pc.User.HasNPWP() {
+		annualTax = annualTax.MulDiv(noNPWPSurchargePercent, 100)
+	}
+
+	tax := annualTax - withheld
+	switch {
+	case tax > 0:
+		return []model.PayslipItem{{
+			Code:     model.PayCodeIncomeTax,
+			Label:    "PPh 21 (annual reconciliation)",
+			Type:     model.PayslipItemDeduction,
+			Quantity: 1,
+			Rate:     tax,
+			Amount:   tax,
+		}}, nil
+	case tax < 0:
+		return []model.PayslipItem{{
+			Code:     model.PayCodeIncomeTax,
+			Label:    "PPh 21 refund (annual reconciliation)",
+			Type:     model.PayslipItemEarning,
+			Quantity: 1,
+			Rate:     -tax,
+			Amount:   -tax,
+		}}, nil
+	}
+	return nil, nil
+}
+
+// progressiveTax applies the brackets layer by layer to taxableIncome.
+func progressiveTax(brackets []model.TaxBracket, taxableIncome model.Money) model.Money {
+	var weighted model.Money // sum of layer * basis points, rounded once below
+	var lower model.Money
+	for _, bracket := range brackets {
+		if taxableIncome <= lower {
+			break
+		}
+		upper := taxableIncome
+		if bracket.MaxIncome > 0 {
+			upper = taxableIncome.Min(bracket.MaxIncome)
+		}
+		weighted += (upper - lower) * model.Money(bracket.RateBasisPoints)
+		lower = upper
+	}
+	return weighted.MulDiv(1, 10_000)
+}
+
+func findPTKP(table *model.TaxTable, status string) (model.TaxPTKP, bool) {
+	for _, ptkp := range table.PTKP {
+		if ptkp.Status == status {
+			return ptkp, true
+		}
+	}
+	return model.TaxPTKP{}, false
+}
+
+// findTERRate expects the rates of a category ordered by MaxIncome with the
+// open-ended row last, as the tax repository returns them.
+func findTERRate(table *model.TaxTable, category string, gross model.Money) (int64, bool) {
+	for _, rate := range table.TERRates {
+		if rate.Category != category {
+			continue
+		}
+		if rate.MaxIncome == 0 || gross <= rate.MaxIncome {
+			return rate.RateBasisPoints, true
+		}
+	}
+	return 0, false
+}
+
+// incomeTaxWithheld is the net PPh 21 on the items: withholdings minus refunds.
+func incomeTaxWithheld(items []model.PayslipItem) model.Money {
+	var tax model.Money
+	for _, item := range items {
+		if item.Code != model.PayCodeIncomeTax {
+			continue
+		}
+		if item.Type == model.PayslipItemDeduction {
+			tax += item.Amount
+		} else {
+			tax -= item.Amount
+		}
+	}
+	return tax
+}
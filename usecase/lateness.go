@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"fmt"
+	"payroll/domain/model"
+)
+
+// Punctuality totals the late arrivals and early departures of a period.
+type Punctuality struct {
+	LateCount         int
+	LateMinutes       int
+	EarlyLeaveCount   int
+	EarlyLeaveMinutes int
+}
+
+// Punctuality summarizes the lateness recorded on the period's attendance.
+func (pc *PayContext) Punctuality() Punctuality {
+	var summary Punctuality
+	for _, attendance := range pc.Attendances {
+		if attendance.LateMinutes > 0 {
+			summary.LateCount++
+			summary.LateMinutes += attendance.LateMinutes
+		}
+		if attendance.EarlyLeaveMinutes > 0 {
+			summary.EarlyLeaveCount++
+			summary.EarlyLeaveMinutes += attendance.EarlyLeaveMinutes
+		}
+	}
+	return summary
+}
+
+// LatenessComponent deducts late arrivals and early departures under the
+// company's lateness policy, one line for each. It runs after income tax
+// and, like other deductions, never brings net pay below the floor; the
+// part that does not fit is waived rather than carried over.
+type LatenessComponent struct {
+	// HourlyDivisor turns the monthly wage into the hourly wage for the
+	// hourly_wage policy.
+	HourlyDivisor int64
+}
+
+func (LatenessComponent) Code() string {
+	return model.PayCodeLateness
+}
+
+func (c LatenessComponent) Calculate(pc *PayContext) ([]model.PayslipItem, error) {
+	company := pc.User.Company
+	if company == nil || !company.LatenessPolicy.IsValid() || company.LatenessPolicy == model.LatenessNone {
+		return nil, nil
+	}
+
+	divisor := c.HourlyDivisor
+	if divisor <= 0 {
+		divisor = DefaultOvertimeRateSchedule().HourlyDivisor
+	}
+
+	summary := pc.Punctuality()
+	lines := []struct {
+		label   string
+		count   int
+		minutes int
+	}{
+		{"Late arrival", summary.LateCount, summary.LateMinutes},
+		{"Early departure", summary.EarlyLeaveCount, summary.EarlyLeaveMinutes},
+	}
+
+	available := (pc.NetPay() - pc.NetPayFloor).Max(0)
+	var items []model.PayslipItem
+	for _, line := range lines {
+		if line.count == 0 {
+			continue
+		}
+
+		var quantity float64
+		var rate, amount model.Money
+		switch company.LatenessPolicy {
+		case model.LatenessPerMinute:
+			quantity, rate = float64(line.minutes), company.LatenessRate
+			amount = rate.MulDiv(int64(line.minutes), 1)
+		case model.LatenessPerIncident:
+			quantity, rate = float64(line.count), company.LatenessRate
+			amount = rate.MulDiv(int64(line.count), 1)
+		case model.LatenessHourlyWage:
+			wage := pc.MonthlyWage()
+			quantity, rate = float64(line.minutes)/60, wage.MulDiv(1, divisor)
+			amount = wage.MulDiv(int64(line.minutes), divisor*60)
+		}
+
+		amount = amount.Min(available)
+		if amount <= 0 {
+			continue
+		}
+		available -= amount
+		items = append(items, model.PayslipItem{
+			Code:     model.PayCodeLateness,
+			Label:    fmt.Sprintf("%s (%d times, %d minutes)", line.label, line.count, line.minutes),
+			Type:     model.PayslipItemDeduction,
+			Quantity: quantity,
+			Rate:     rate,
+			Amount:   amount,
+		})
+	}
+	return items, nil
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"time"
+)
+
+// PaidLeaveDays counts the scheduled working days from startDate to endDate
+// the employee spent on paid leave. Unpaid leave days are left out and so
+// are paid as absences.
+func (pc *PayContext) PaidLeaveDays(startDate, endDate time.Time) int {
+	attended := make(map[string]bool, len(pc.Attendances))
+	for _, attendance := range pc.Attendances {
+		attended[dateKey(attendance.Date)] = true
+	}
+
+	days := 0
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		if attended[dateKey(d)] || !pc.Schedule.IsWorkingDay(d, pc.Holidays) {
+			continue
+		}
+		for i := range pc.Leaves {
+			if pc.Leaves[i].LeaveType.Paid && pc.Leaves[i].Covers(d) {
+				days++
+				break
+			}
+		}
+	}
+	return days
+}
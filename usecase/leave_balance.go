@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+	"payroll/domain/model"
+	"payroll/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AccruedLeaveDays is the leave of leaveType user has earned in year by
+// asOf: a twelfth of AccrualDaysPerYear for every month of the year that
+// has ended by asOf, on whose last day the user was still employed and had
+// served ServiceMonthsRequired months.
+func AccruedLeaveDays(leaveType *model.LeaveType, user *model.User, year int, asOf time.Time) float64 {
+	if !leaveType.TracksBalance() {
+		return 0
+	}
+
+	start := user.CreatedAt
+	if user.HireDate != nil {
+		start = *user.HireDate
+	}
+	eligible := start.AddDate(0, leaveType.ServiceMonthsRequired, 0)
+
+	months := 0
+	for month := time.January; month <= time.December; month++ {
+		monthEnd := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+		if dateKeyAfter(monthEnd, asOf) {
+			break
+		}
+		if user.TerminationDate != nil && dateKeyAfter(monthEnd, *user.TerminationDate) {
+			break
+		}
+		if dateKeyAfter(eligible, monthEnd) {
+			continue
+		}
+		months++
+	}
+	return roundLeaveDays(leaveType.AccrualDaysPerYear * float64(months) / 12)
+}
+
+// LeavePayoutComponent pays the remaining balances of an employee who leaves
+// in the period at the daily rate of the fixed divisor, one line per leave
+// type.
+type LeavePayoutComponent struct{}
+
+func (LeavePayoutComponent) Code() string {
+	return model.PayCodeLeavePayout
+}
+
+func (LeavePayoutComponent) Calculate(pc *PayContext) ([]model.PayslipItem, error) {
+	divisor := pc.ProrationDivisor
+	if divisor <= 0 {
+		divisor = model.DefaultProrationDivisor
+	}
+	salary := pc.Salary()
+
+	var items []model.PayslipItem
+	for i := range pc.LeaveBalances {
+		balance := &pc.LeaveBalances[i]
+		days := roundLeaveDays(balance.Available())
+		if days <= 0 {
+			continue
+		}
+		items = append(items, model.PayslipItem{
+			Code:           model.PayCodeLeavePayout,
+			Label:          "Leave payout (" + balance.LeaveType.Name + ")",
+			Type:           model.PayslipItemEarning,
+			Quantity:       days,
+			Rate:           salary.MulDiv(1, int64(divisor)),
+			Amount:         salary.MulDiv(int64(math.Round(days*100)), int64(divisor)*100),
+			Taxable:        true,
+			LeaveBalanceID: &balance.ID,
+		})
+	}
+	return items, nil
+}
+
+func roundLeaveDays(days float64) float64 {
+	return math.Round(days*100) / 100
+}
+
+// loadLeaveBalance returns user's balance of leaveType for year, brought up
+// to date as of asOf and saved. The first balance of a year is opened by
+// closing the previous year's, carrying over what the leave type allows.
+func loadLeaveBalance(leaveRepo repositories.LeaveRepository, user *model.User, leaveType *model.LeaveType, year int, asOf time.Time) (*model.LeaveBalance, error) {
+	balance, closed, err := leaveBalanceAsOf(leaveRepo, user, leaveType, year, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if err := saveLeaveBalances(leaveRepo, closed, balance); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// leaveBalanceAsOf works out what loadLeaveBalance would save without saving
+// anything. A balance the year has not opened yet has no ID; closed is the
+// previous year's balance it closes, if any.
+func leaveBalanceAsOf(leaveRepo repositories.LeaveRepository, user *model.User, leaveType *model.LeaveType, year int, asOf time.Time) (balance, closed *model.LeaveBalance, err error) {
+	if yearEnd := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC); dateKeyAfter(asOf, yearEnd) {
+		asOf = yearEnd
+	}
+
+	balance, err = leaveRepo.GetBalance(user.ID, leaveType.ID, year)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, err
+	}
+	if balance == nil {
+		balance = &model.LeaveBalance{
+			UserID:      user.ID,
+			LeaveTypeID: leaveType.ID,
+			Year:        year,
+		}
+
+		previous, err := leaveRepo.GetBalance(user.ID, leaveType.ID, year-1)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, err
+		}
+		if previous != nil {
+			previousEnd := time.Date(year-1, time.December, 31, 0, 0, 0, 0, time.UTC)
+			previous.Accrued = AccruedLeaveDays(leaveType, user, year-1, previousEnd)
+			previous.LapseCarryOver(previousEnd)
+			balance.CarriedOver = previous.CloseYear(leaveType.CarryOverCap)
+			closed = previous
+		}
+		if balance.CarriedOver > 0 && leaveType.CarryOverExpiryMonths > 0 {
+			expiresAt := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, leaveType.CarryOverExpiryMonths, 0)
+			balance.CarryOverExpiresAt = &expiresAt
+		}
+	}
+
+	balance.Accrued = AccruedLeaveDays(leaveType, user, year, asOf)
+	balance.LapseCarryOver(asOf)
+	balance.LeaveType = *leaveType
+	return balance, closed, nil
+}
+
+// saveLeaveBalances creates the balances without an ID and updates the
+// others, in order. Nil balances are skipped.
+func saveLeaveBalances(leaveRepo repositories.LeaveRepository, balances ...*model.LeaveBalance) error {
+	for _, balance := range balances {
+		if balance == nil {
+			continue
+		}
+		if balance.ID == 0 {
+			if err := leaveRepo.CreateBalance(balance); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := leaveRepo.UpdateBalance(balance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,536 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"payroll/domain/dto"
+	"payroll/domain/model"
+	"payroll/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func NewLeaveUsecase(
+	leaveRepo repositories.LeaveRepository,
+	userRepo repositories.UserRepository,
+	attendanceRepo repositories.AttendanceRepository,
+	holidayRepo repositories.HolidayRepository,
+	scheduleRepo repositories.ScheduleRepository,
+	auditRepo repositories.AuditRepository,
+	transactor repositories.Transactor,
+) *LeaveUsecase {
+	return &LeaveUsecase{
+		leaveRepo:      leaveRepo,
+		userRepo:       userRepo,
+		attendanceRepo: attendanceRepo,
+		holidayRepo:    holidayRepo,
+		scheduleRepo:   scheduleRepo,
+		auditRepo:      auditRepo,
+		transactor:     transactor,
+	}
+}
+
+func (l *LeaveUsecase) GetLeaveTypes() ([]model.LeaveType, error) {
+	return l.leaveRepo.GetTypes()
+}
+
+func (l *LeaveUsecase) CreateLeaveType(req *dto.LeaveTypeRequest, userID uint, ipAddress, requestID string) (*model.LeaveType, error) {
+	leaveType := &model.LeaveType{
+		BaseModel: model.BaseModel{
+			CreatedBy: &userID,
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		Paid:     true,
+		IsActive: true,
+	}
+	applyLeaveType(leaveType, req)
+
+	if err := l.leaveRepo.CreateType(leaveType); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(leaveType)
+	l.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "CREATE",
+		TableName: "leave_types",
+		RecordID:  &leaveType.ID,
+		NewData:   string(newData),
+	})
+
+	return leaveType, nil
+}
+
+func (l *LeaveUsecase) UpdateLeaveType(id uint, req *dto.LeaveTypeRequest, userID uint, ipAddress, requestID string) (*model.LeaveType, error) {
+	leaveType, err := l.leaveRepo.GetTypeByID(id)
+	if err != nil {
+		return nil, errors.New("leave type not found")
+	}
+	oldData, _ := json.Marshal(leaveType)
+
+	applyLeaveType(leaveType, req)
+	leaveType.UpdatedBy = &userID
+	leaveType.IPAddress = ipAddress
+	leaveType.RequestID = requestID
+
+	if err := l.leaveRepo.UpdateType(leaveType); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(leaveType)
+	l.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "UPDATE",
+		TableName: "leave_types",
+		RecordID:  &leaveType.ID,
+		OldData:   string(oldData),
+		NewData:   string(newData),
+	})
+
+	return leaveType, nil
+}
+
+func applyLeaveType(leaveType *model.LeaveType, req *dto.LeaveTypeRequest) {
+	leaveType.Code = req.Code
+	leaveType.Name = req.Name
+	if req.Paid != nil {
+		leaveType.Paid = *req.Paid
+	}
+	if req.IsActive != nil {
+		leaveType.IsActive = *req.IsActive
+	}
+	leaveType.AccrualDaysPerYear = req.AccrualDaysPerYear
+	leaveType.ServiceMonthsRequired = req.ServiceMonthsRequired
+	leaveType.CarryOverCap = req.CarryOverCap
+	leaveType.CarryOverExpiryMonths = req.CarryOverExpiryMonths
+	leaveType.PayOutOnTermination = req.PayOutOnTermination
+}
+
+// GetLeaveRequests lists leave requests. A zero employeeID or empty status
+// matches any.
+func (l *LeaveUsecase) GetLeaveRequests(employeeID uint, status string) ([]model.LeaveRequest, error) {
+	return l.leaveRepo.GetRequests(employeeID, model.LeaveStatus(status))
+}
+
+// SubmitLeave files a leave request for the employee, pending approval.
+func (l *LeaveUsecase) SubmitLeave(userID uint, req *dto.LeaveRequestRequest, ipAddress, requestID string) (*model.LeaveRequest, error) {
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, errors.New("invalid start date format")
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, errors.New("invalid end date format")
+	}
+	if endDate.Before(startDate) {
+		return nil, errors.New("end date must not be before start date")
+	}
+
+	leaveType, err := l.leaveRepo.GetTypeByID(req.LeaveTypeID)
+	if err != nil || !leaveType.IsActive {
+		return nil, errors.New("leave type not found")
+	}
+
+	overlapping, err := l.leaveRepo.GetOverlapping(userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if len(overlapping) > 0 {
+		return nil, errors.New("leave already requested for these dates")
+	}
+
+	if err := l.checkNoAttendance(userID, startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	days, err := l.countLeaveDays(userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if days == 0 {
+		return nil, errors.New("leave covers no working days")
+	}
+
+	if leaveType.TracksBalance() {
+		if _, err := l.checkBalance(userID, leaveType, startDate, days); err != nil {
+			return nil, err
+		}
+	}
+
+	request := &model.LeaveRequest{
+		BaseModel: model.BaseModel{
+			CreatedBy: &userID,
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:      userID,
+		LeaveTypeID: leaveType.ID,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Days:        days,
+		Reason:      req.Reason,
+		Status:      model.LeavePending,
+		LeaveType:   *leaveType,
+	}
+
+	if err := l.leaveRepo.Create(request); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(request)
+	l.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "CREATE",
+		TableName: "leave_requests",
+		RecordID:  &request.ID,
+		NewData:   string(newData),
+	})
+
+	return request, nil
+}
+
+// ApproveLeave approves a pending request and takes its days from the
+// employee's balance in one transaction, with the request and balance rows
+// locked so concurrent approvals cannot spend the same days.
+func (l *LeaveUsecase) ApproveLeave(id uint, req *dto.LeaveReviewRequest, userID uint, ipAddress, requestID string) (*model.LeaveRequest, error) {
+	var approved *model.LeaveRequest
+	err := l.transactor.WithinTransaction(func(repos *repositories.Repositories) error {
+		var err error
+		approved, err = l.withRepositories(repos).approveLeave(id, req, userID, ipAddress, requestID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return approved, nil
+}
+
+// withRepositories returns a copy of the usecase that works through repos,
+// such as the repositories of a transaction.
+func (l *LeaveUsecase) withRepositories(repos *repositories.Repositories) *LeaveUsecase {
+	tx := *l
+	tx.leaveRepo = repos.Leave
+	tx.userRepo = repos.User
+	tx.attendanceRepo = repos.Attendance
+	tx.holidayRepo = repos.Holiday
+	tx.scheduleRepo = repos.Schedule
+	tx.auditRepo = repos.Audit
+	return &tx
+}
+
+// approveLeave does the work of ApproveLeave inside its transaction.
+func (l *LeaveUsecase) approveLeave(id uint, req *dto.LeaveReviewRequest, userID uint, ipAddress, requestID string) (*model.LeaveRequest, error) {
+	request, err := l.leaveRepo.LockRequest(id)
+	if err != nil {
+		return nil, errors.New("leave request not found")
+	}
+	if request.Status != model.LeavePending {
+		return nil, errors.New("leave request is not pending")
+	}
+
+	// Attendance may have been recorded while the request was pending.
+	if err := l.checkNoAttendance(request.UserID, request.StartDate, request.EndDate); err != nil {
+		return nil, err
+	}
+
+	var balance *model.LeaveBalance
+	if request.LeaveType.TracksBalance() {
+		// Lock the balance before reading it; a balance the year has not
+		// opened yet is created by checkBalance
+		if _, err := l.leaveRepo.LockBalance(request.UserID, request.LeaveTypeID, request.StartDate.Year()); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		balance, err = l.checkBalance(request.UserID, &request.LeaveType, request.StartDate, request.Days)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	approved, err := l.review(request, model.LeaveApproved, "APPROVE", req.Note, userID, ipAddress, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if balance != nil {
+		balance.Used += float64(request.Days)
+		if err := l.leaveRepo.UpdateBalance(balance); err != nil {
+			return nil, err
+		}
+	}
+	return approved, nil
+}
+
+func (l *LeaveUsecase) RejectLeave(id uint, req *dto.LeaveReviewRequest, userID uint, ipAddress, requestID string) (*model.LeaveRequest, error) {
+	request, err := l.leaveRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("leave request not found")
+	}
+	if request.Status != model.LeavePending {
+		return nil, errors.New("leave request is not pending")
+	}
+
+	return l.review(request, model.LeaveRejected, "REJECT", req.Note, userID, ipAddress, requestID)
+}
+
+// CancelLeave withdraws one of the employee's own requests. Approved leave
+// can only be cancelled before it starts.
+func (l *LeaveUsecase) CancelLeave(id uint, userID uint, ipAddress, requestID string) (*model.LeaveRequest, error) {
+	request, err := l.leaveRepo.GetByID(id)
+	if err != nil || request.UserID != userID {
+		return nil, errors.New("leave request not found")
+	}
+
+	switch request.Status {
+	case model.LeavePending:
+	case model.LeaveApproved:
+		if !dateKeyAfter(request.StartDate, time.Now()) {
+			return nil, errors.New("leave has already started")
+		}
+	default:
+		return nil, errors.New("leave request cannot be cancelled")
+	}
+	oldData, _ := json.Marshal(request)
+
+	wasApproved := request.Status == model.LeaveApproved
+	request.Status = model.LeaveCancelled
+	request.UpdatedBy = &userID
+	request.IPAddress = ipAddress
+	request.RequestID = requestID
+
+	if err := l.leaveRepo.Update(request); err != nil {
+		return nil, err
+	}
+
+	// Give the days back to the balance they were taken from
+	if wasApproved && request.LeaveType.TracksBalance() {
+		if balance, _ := l.leaveRepo.GetBalance(request.UserID, request.LeaveTypeID, request.StartDate.Year()); balance != nil {
+			balance.Used -= float64(request.Days)
+			if err := l.leaveRepo.UpdateBalance(balance); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(request)
+	l.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "CANCEL",
+		TableName: "leave_requests",
+		RecordID:  &request.ID,
+		OldData:   string(oldData),
+		NewData:   string(newData),
+	})
+
+	return request, nil
+}
+
+func (l *LeaveUsecase) review(request *model.LeaveRequest, status model.LeaveStatus, action, note string, userID uint, ipAddress, requestID string) (*model.LeaveRequest, error) {
+	oldData, _ := json.Marshal(request)
+
+	now := time.Now()
+	request.Status = status
+	request.ReviewedBy = &userID
+	request.ReviewedAt = &now
+	request.ReviewNote = note
+	request.UpdatedBy = &userID
+	request.IPAddress = ipAddress
+	request.RequestID = requestID
+
+	if err := l.leaveRepo.Update(request); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(request)
+	l.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    action,
+		TableName: "leave_requests",
+		RecordID:  &request.ID,
+		OldData:   string(oldData),
+		NewData:   string(newData),
+	})
+
+	return request, nil
+}
+
+func (l *LeaveUsecase) checkNoAttendance(userID uint, startDate, endDate time.Time) error {
+	attendances, err := l.attendanceRepo.GetByUserAndPeriod(userID, startDate, endDate)
+	if err != nil {
+		return err
+	}
+	if len(attendances) > 0 {
+		return fmt.Errorf("attendance already recorded on %s", attendances[0].Date.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// countLeaveDays counts the days from startDate to endDate the employee is
+// scheduled to work, leaving out rest days and holidays.
+func (l *LeaveUsecase) countLeaveDays(userID uint, startDate, endDate time.Time) (int, error) {
+	assignments, err := l.scheduleRepo.GetAssignmentsByUser(userID)
+	if err != nil {
+		return 0, err
+	}
+	holidays, err := l.holidayRepo.GetByRange(startDate, endDate)
+	if err != nil {
+		return 0, err
+	}
+
+	schedule := NewEmployeeSchedule(assignments)
+	calendar := NewHolidayCalendar(holidays)
+	days := 0
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		if schedule.IsWorkingDay(d, calendar) {
+			days++
+		}
+	}
+	return days, nil
+}
+
+// checkBalance returns the balance a request of days from startDate draws
+// on, failing when it does not hold enough days.
+func (l *LeaveUsecase) checkBalance(employeeID uint, leaveType *model.LeaveType, startDate time.Time, days int) (*model.LeaveBalance, error) {
+	now := time.Now()
+	if startDate.Year() > now.Year() {
+		return nil, fmt.Errorf("%s for %d cannot be requested yet", leaveType.Name, startDate.Year())
+	}
+
+	employee, err := l.userRepo.GetByID(employeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+	balance, err := loadLeaveBalance(l.leaveRepo, employee, leaveType, startDate.Year(), now)
+	if err != nil {
+		return nil, err
+	}
+	if available := balance.Available(); available < float64(days) {
+		return nil, fmt.Errorf("insufficient %s balance: %.2f days available", leaveType.Name, available)
+	}
+	return balance, nil
+}
+
+// GetLeaveBalances returns the employee's balance of every leave type that
+// keeps one, up to date, for year.
+func (l *LeaveUsecase) GetLeaveBalances(employeeID uint, year int) ([]model.LeaveBalance, error) {
+	now := time.Now()
+	if year == 0 {
+		year = now.Year()
+	}
+	if year > now.Year() {
+		return nil, fmt.Errorf("leave balances for %d are not open yet", year)
+	}
+
+	employee, err := l.userRepo.GetByID(employeeID)
+	if err != nil || employee.Role != "employee" {
+		return nil, errors.New("employee not found")
+	}
+
+	leaveTypes, err := l.leaveRepo.GetTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]model.LeaveBalance, 0)
+	for i := range leaveTypes {
+		if !leaveTypes[i].IsActive || !leaveTypes[i].TracksBalance() {
+			continue
+		}
+		balance, err := loadLeaveBalance(l.leaveRepo, employee, &leaveTypes[i], year, now)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, *balance)
+	}
+	return balances, nil
+}
+
+// AccrueLeave brings every employee's leave balances up to date as of the
+// given date, opening the year's balances and carrying over from the
+// previous year where needed. It is safe to run more than once.
+func (l *LeaveUsecase) AccrueLeave(req *dto.LeaveAccrualRequest, userID uint, ipAddress, requestID string) (int, error) {
+	asOf := time.Now()
+	if req.AsOf != "" {
+		var err error
+		asOf, err = time.Parse("2006-01-02", req.AsOf)
+		if err != nil {
+			return 0, errors.New("invalid as of date format")
+		}
+	}
+
+	users, err := l.userRepo.GetAll()
+	if err != nil {
+		return 0, err
+	}
+	leaveTypes, err := l.leaveRepo.GetTypes()
+	if err != nil {
+		return 0, err
+	}
+
+	yearStart := time.Date(asOf.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	updated := 0
+	for i := range users {
+		user := &users[i]
+		if user.Role != model.RoleEmployee {
+			continue
+		}
+		if user.TerminationDate != nil && user.TerminationDate.Before(yearStart) {
+			continue
+		}
+
+		for j := range leaveTypes {
+			if !leaveTypes[j].IsActive || !leaveTypes[j].TracksBalance() {
+				continue
+			}
+			if _, err := loadLeaveBalance(l.leaveRepo, user, &leaveTypes[j], asOf.Year(), asOf); err != nil {
+				return updated, fmt.Errorf("employee %d: %w", user.ID, err)
+			}
+			updated++
+		}
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(map[string]interface{}{
+		"as_of":    asOf.Format("2006-01-02"),
+		"balances": updated,
+	})
+	l.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "LEAVE_ACCRUED",
+		TableName: "leave_balances",
+		NewData:   string(newData),
+	})
+
+	return updated, nil
+}
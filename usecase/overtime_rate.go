@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"fmt"
+	"payroll/domain/model"
+	"strconv"
+	"time"
+)
+
+type OvertimeDayType string
+
+const (
+	OvertimeWorkday       OvertimeDayType = "workday"
+	OvertimeRestDay       OvertimeDayType = "rest_day"
+	OvertimePublicHoliday OvertimeDayType = "public_holiday"
+)
+
+// overtimeDayTypes fixes the order day types appear on the payslip.
+var overtimeDayTypes = []OvertimeDayType{OvertimeWorkday, OvertimeRestDay, OvertimePublicHoliday}
+
+func (d OvertimeDayType) label() string {
+	switch d {
+	case OvertimeRestDay:
+		return "rest day"
+	case OvertimePublicHoliday:
+		return "public holiday"
+	}
+	return "workday"
+}
+
+// OvertimeTier prices the overtime hours of one day up to UpToHours
+// (cumulative, counted from the first overtime hour of that day). The last
+// tier of a ladder leaves UpToHours at zero to cover every remaining hour.
+type OvertimeTier struct {
+	UpToHours         int
+	MultiplierPercent int64 // 150 = 1.5x the hourly rate
+}
+
+// OvertimeRateSchedule holds the multiplier ladder for each day type and the
+// divisor that turns the monthly wage into the hourly base.
+type OvertimeRateSchedule struct {
+	HourlyDivisor int64
+	Ladders       map[OvertimeDayType][]OvertimeTier
+}
+
+// DefaultOvertimeRateSchedule follows the statutory rules for a five-day work
+// week: an hourly base of 1/173 of the monthly wage, 1.5x for the first hour
+// and 2x afterwards on workdays, and 2x for the first eight hours, 3x for the
+// ninth and 4x beyond on rest days and public holidays.
+func DefaultOvertimeRateSchedule() OvertimeRateSchedule {
+	restDay := []OvertimeTier{
+		{UpToHours: 8, MultiplierPercent: 200},
+		{UpToHours: 9, MultiplierPercent: 300},
+		{MultiplierPercent: 400},
+	}
+	return OvertimeRateSchedule{
+		HourlyDivisor: 173,
+		Ladders: map[OvertimeDayType][]OvertimeTier{
+			OvertimeWorkday: {
+				{UpToHours: 1, MultiplierPercent: 150},
+				{MultiplierPercent: 200},
+			},
+			OvertimeRestDay:       restDay,
+			OvertimePublicHoliday: restDay,
+		},
+	}
+}
+
+// ClassifyOvertimeDay returns the day type an overtime on date is paid at.
+// Days without a scheduled shift and collective leave days are paid as rest
+// days.
+func (pc *PayContext) ClassifyOvertimeDay(date time.Time) OvertimeDayType {
+	if holiday, ok := pc.Holidays.Lookup(date); ok {
+		if holiday.Type == model.HolidayPublic {
+			return OvertimePublicHoliday
+		}
+		return OvertimeRestDay
+	}
+	if pc.Schedule.ShiftOn(date) == nil {
+		return OvertimeRestDay
+	}
+	return OvertimeWorkday
+}
+
+// splitOvertimeMinutes distributes the overtime minutes of a single day over
+// the tiers of ladder and returns the minutes that fall in each tier.
+func splitOvertimeMinutes(ladder []OvertimeTier, minutes int64) []int64 {
+	split := make([]int64, len(ladder))
+	var tierStart int64
+	for i, tier := range ladder {
+		if minutes <= tierStart {
+			break
+		}
+		tierEnd := minutes
+		if tier.UpToHours > 0 {
+			tierEnd = min(minutes, int64(tier.UpToHours)*60)
+		}
+		if tierEnd > tierStart {
+			split[i] = tierEnd - tierStart
+			tierStart = tierEnd
+		}
+	}
+	return split
+}
+
+func overtimeTierLabel(dayType OvertimeDayType, ladder []OvertimeTier, index int) string {
+	firstHour := 1
+	if index > 0 {
+		firstHour = ladder[index-1].UpToHours + 1
+	}
+	lastHour := ladder[index].UpToHours
+
+	var hours string
+	switch {
+	case lastHour == 0:
+		hours = fmt.Sprintf("hour %d+", firstHour)
+	case lastHour == firstHour:
+		hours = fmt.Sprintf("hour %d", firstHour)
+	default:
+		hours = fmt.Sprintf("hours %d-%d", firstHour, lastHour)
+	}
+
+	multiplier := strconv.FormatFloat(float64(ladder[index].MultiplierPercent)/100, 'f', -1, 64)
+	return fmt.Sprintf("Overtime (%s, %s, %sx)", dayType.label(), hours, multiplier)
+}
@@ -9,22 +9,40 @@ import (
 	"time"
 )
 
-func NewOvertimeUsecase(overtimeRepo repositories.OvertimeRepository, auditRepo repositories.AuditRepository) *OvertimeUsecase {
+func NewOvertimeUsecase(overtimeRepo repositories.OvertimeRepository, userRepo repositories.UserRepository, payrollRepo repositories.PayrollRepository, auditRepo repositories.AuditRepository) *OvertimeUsecase {
 	return &OvertimeUsecase{
 		overtimeRepo: overtimeRepo,
+		userRepo:     userRepo,
+		payrollRepo:  payrollRepo,
 		auditRepo:    auditRepo,
 	}
 }
 
 func (o *OvertimeUsecase) SubmitOvertime(userID uint, req *dto.OvertimeRequest, ipAddress, requestID string) error {
+	return o.createOvertime(userID, req, false, userID, ipAddress, requestID)
+}
+
+// RecordOvertime enters overtime for an employee on an administrator's
+// behalf, also in processed periods so a correction run can pay it.
+func (o *OvertimeUsecase) RecordOvertime(employeeID uint, req *dto.OvertimeRequest, userID uint, ipAddress, requestID string) error {
+	return o.createOvertime(employeeID, req, true, userID, ipAddress, requestID)
+}
+
+// createOvertime stores the overtime of employeeID entered by userID.
+func (o *OvertimeUsecase) createOvertime(employeeID uint, req *dto.OvertimeRequest, correction bool, userID uint, ipAddress, requestID string) error {
 	// Parse date
 	date, err := time.Parse("2006-01-02", req.Date)
 	if err != nil {
 		return errors.New("invalid date format")
 	}
 
+	// Check the date is still open for submissions
+	if err := checkSubmissionDate(o.userRepo, o.payrollRepo, employeeID, date, correction); err != nil {
+		return err
+	}
+
 	// Check if overtime already submitted for this date
-	existing, _ := o.overtimeRepo.GetByUserAndDate(userID, date)
+	existing, _ := o.overtimeRepo.GetByUserAndDate(employeeID, date)
 	if existing != nil {
 		return errors.New("overtime already submitted for this date")
 	}
@@ -40,7 +58,7 @@ func (o *OvertimeUsecase) SubmitOvertime(userID uint, req *dto.OvertimeRequest,
 			IPAddress: ipAddress,
 			RequestID: requestID,
 		},
-		UserID:      userID,
+		UserID:      employeeID,
 		Date:        date,
 		Hours:       req.Hours,
 		Description: req.Description,
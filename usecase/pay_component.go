@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"fmt"
+	"payroll/domain/model"
+)
+
+// PayComponent contributes labeled line items (earnings or deductions) to a
+// payslip. Components run in registration order and can read the lines
+// produced before them, so taxes and deductions are registered after the
+// earnings they depend on.
+type PayComponent interface {
+	Code() string
+	Calculate(pc *PayContext) ([]model.PayslipItem, error)
+}
+
+// PayContext holds everything a component needs to price one employee for
+// one period. It is filled by the payroll run before any component executes.
+type PayContext struct {
+	User             *model.User
+	Period           *model.PayrollPeriod
+	WorkingDays      int
+	CalendarDays     int
+	ProrationPolicy  model.ProrationPolicy
+	ProrationDivisor int
+	Holidays         HolidayCalendar
+	Schedule         *EmployeeSchedule
+	TaxTable         *model.TaxTable
+	// PayFrequency is the frequency of the period's pay group; empty for
+	// periods outside a pay group, which are paid monthly.
+	PayFrequency model.PayFrequency
+
+	// SalarySegments split the period by the salary in effect, from the
+	// employee's compensation history. Empty means User.Salary throughout.
+	SalarySegments []SalarySegment
+
+	Allowances  []model.AllowanceAssignment
+	Attendances []model.Attendance
+	// Leaves is the approved leave overlapping the period.
+	Leaves []model.LeaveRequest
+	// LeaveBalances are paid out when the employee leaves in the period.
+	LeaveBalances  []model.LeaveBalance
+	Overtimes      []model.Overtime
+	Reimbursements []model.Reimbursement
+
+	// Payslips of earlier periods in the same tax year, loaded for the
+	// December income tax reconciliation.
+	PriorPayslips []model.Payslip
+
+	// Earlier periods owed back pay after a retroactive salary change.
+	RetroPeriods []RetroPeriod
+
+	// Active deductions of the employee, oldest first, and the net pay they
+	// may not go below.
+	Deductions  []model.Deduction
+	NetPayFloor model.Money
+	// DeductionTransactions records what DeductionComponent applied; the run
+	// stores them with the payslip and updates the deductions from them.
+	DeductionTransactions []model.DeductionTransaction
+
+	// Items produced so far by the components that already ran.
+	Items []model.PayslipItem
+}
+
+// Salary is the monthly salary in effect at the end of the period.
+func (pc *PayContext) Salary() model.Money {
+	if len(pc.SalarySegments) == 0 {
+		return pc.User.Salary
+	}
+	return pc.SalarySegments[len(pc.SalarySegments)-1].Salary
+}
+
+// MonthlyWage is the contractual monthly wage overtime and contributions
+// are based on: the salary plus fixed allowances.
+func (pc *PayContext) MonthlyWage() model.Money {
+	wage := pc.Salary()
+	for _, assignment := range pc.Allowances {
+		if assignment.Allowance.Basis == model.AllowanceFixed {
+			wage += assignment.EffectiveAmount()
+		}
+	}
+	return wage
+}
+
+func (pc *PayContext) Total(itemType model.PayslipItemType) model.Money {
+	var total model.Money
+	for _, item := range pc.Items {
+		if item.Type == itemType {
+			total += item.Amount
+		}
+	}
+	return total
+}
+
+func (pc *PayContext) SumByCode(code string) model.Money {
+	var total model.Money
+	for _, item := range pc.Items {
+		if item.Code == code {
+			total += item.Amount
+		}
+	}
+	return total
+}
+
+// TaxableIncome is the PPh 21 gross of the items produced so far.
+func (pc *PayContext) TaxableIncome() model.Money {
+	var total model.Money
+	for _, item := range pc.Items {
+		if item.Taxable {
+			total += item.Amount
+		}
+	}
+	return total
+}
+
+// TaxDeductible is the sum of employee contributions deductible from income.
+func (pc *PayContext) TaxDeductible() model.Money {
+	var total model.Money
+	for _, item := range pc.Items {
+		if item.TaxDeductible {
+			total += item.Amount
+		}
+	}
+	return total
+}
+
+func (pc *PayContext) NetPay() model.Money {
+	return pc.Total(model.PayslipItemEarning) - pc.Total(model.PayslipItemDeduction)
+}
+
+// DefaultPayComponents returns the components every payroll run starts with.
+func DefaultPayComponents() []PayComponent {
+	return []PayComponent{
+		BasePayComponent{},
+		RetroPayComponent{},
+		LeavePayoutComponent{},
+		AllowanceComponent{},
+		OvertimeComponent{Schedule: DefaultOvertimeRateSchedule()},
+		ReimbursementComponent{},
+		BPJSComponent{Rates: DefaultBPJSRates()},
+		IncomeTaxComponent{},
+		LatenessComponent{HourlyDivisor: DefaultOvertimeRateSchedule().HourlyDivisor},
+		DeductionComponent{},
+	}
+}
+
+// runPayComponents executes the components in order against pc, appending
+// their items to pc.Items.
+func runPayComponents(components []PayComponent, pc *PayContext) error {
+	for _, component := range components {
+		items, err := component.Calculate(pc)
+		if err != nil {
+			return fmt.Errorf("%s: %w", component.Code(), err)
+		}
+		pc.Items = append(pc.Items, items...)
+	}
+	return nil
+}
+
+// BasePayComponent pays the salary for attended days, prorated with the
+// policy resolved for the employee. When the salary changed during the
+// period, each salary is paid on its own line for the days it was in effect;
+// days outside the employee's employment are not paid.
+type BasePayComponent struct{}
+
+func (BasePayComponent) Code() string {
+	return model.PayCodeBasePay
+}
+
+func (BasePayComponent) Calculate(pc *PayContext) ([]model.PayslipItem, error) {
+	periodBasis := ProrationBasis{
+		PaidDays:       len(pc.Attendances) + pc.PaidLeaveDays(pc.Period.StartDate, pc.Period.EndDate),
+		WorkingDays:    pc.WorkingDays,
+		CalendarDays:   pc.CalendarDays,
+		Divisor:        pc.ProrationDivisor,
+		PeriodsPerYear: pc.PayFrequency.PeriodsPerYear(),
+	}
+
+	if len(pc.SalarySegments) == 0 || len(pc.SalarySegments) == 1 && pc.coversPeriod(pc.SalarySegments[0]) {
+		amount, dailyRate := ProrateBasePay(pc.ProrationPolicy, pc.Salary(), periodBasis)
+		return []model.PayslipItem{{
+			Code:     model.PayCodeBasePay,
+			Label:    "Base pay",
+			Type:     model.PayslipItemEarning,
+			Quantity: float64(periodBasis.PaidDays),
+			Rate:     dailyRate,
+			Amount:   amount,
+			Taxable:  true,
+		}}, nil
+	}
+
+	items := make([]model.PayslipItem, 0, len(pc.SalarySegments))
+	for _, segment := range pc.SalarySegments {
+		segmentBasis := pc.segmentBasis(segment)
+		amount, dailyRate := ProrateSegment(pc.ProrationPolicy, segment.Salary, segmentBasis, periodBasis)
+		items = append(items, model.PayslipItem{
+			Code:     model.PayCodeBasePay,
+			Label:    salarySegmentLabel(segment),
+			Type:     model.PayslipItemEarning,
+			Quantity: float64(segmentBasis.PaidDays),
+			Rate:     dailyRate,
+			Amount:   amount,
+			Taxable:  true,
+		})
+	}
+	return items, nil
+}
+
+// OvertimeComponent prices each overtime day on the tiered ladder of its day
+// type and adds one line per day type and tier.
+type OvertimeComponent struct {
+	Schedule OvertimeRateSchedule
+}
+
+func (OvertimeComponent) Code() string {
+	return model.PayCodeOvertime
+}
+
+func (c OvertimeComponent) Calculate(pc *PayContext) ([]model.PayslipItem, error) {
+	schedule := c.Schedule
+	if schedule.Ladders == nil {
+		schedule = DefaultOvertimeRateSchedule()
+	}
+
+	minutesByTier := make(map[OvertimeDayType][]int64)
+	for _, overtime := range pc.Overtimes {
+		dayType := pc.ClassifyOvertimeDay(overtime.Date)
+		ladder, ok := schedule.Ladders[dayType]
+		if !ok {
+			return nil, fmt.Errorf("no overtime rates for %s", dayType)
+		}
+
+		if minutesByTier[dayType] == nil {
+			minutesByTier[dayType] = make([]int64, len(ladder))
+		}
+		for i, minutes := range splitOvertimeMinutes(ladder, model.HoursToMinutes(overtime.Hours)) {
+			minutesByTier[dayType][i] += minutes
+		}
+	}
+
+	wage := pc.MonthlyWage()
+	var items []model.PayslipItem
+	for _, dayType := range overtimeDayTypes {
+		ladder := schedule.Ladders[dayType]
+		for i, minutes := range minutesByTier[dayType] {
+			if minutes == 0 {
+				continue
+			}
+			multiplier := ladder[i].MultiplierPercent
+			items = append(items, model.PayslipItem{
+				Code:     model.PayCodeOvertime,
+				Label:    overtimeTierLabel(dayType, ladder, i),
+				Type:     model.PayslipItemEarning,
+				Quantity: float64(minutes) / 60,
+				Rate:     wage.MulDiv(multiplier, schedule.HourlyDivisor*100),
+				Amount:   wage.MulDiv(multiplier*minutes, schedule.HourlyDivisor*100*60),
+				Taxable:  true,
+			})
+		}
+	}
+	return items, nil
+}
+
+// ReimbursementComponent passes submitted reimbursements through as earnings.
+type ReimbursementComponent struct{}
+
+func (ReimbursementComponent) Code() string {
+	return model.PayCodeReimbursement
+}
+
+func (ReimbursementComponent) Calculate(pc *PayContext) ([]model.PayslipItem, error) {
+	items := make([]model.PayslipItem, 0, len(pc.Reimbursements))
+	for _, reimbursement := range pc.Reimbursements {
+		items = append(items, model.PayslipItem{
+			Code:     model.PayCodeReimbursement,
+			Label:    reimbursement.Description,
+			Type:     model.PayslipItemEarning,
+			Quantity: 1,
+			Rate:     reimbursement.Amount,
+			Amount:   reimbursement.Amount,
+		})
+	}
+	return items, nil
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"payroll/domain/model"
+	"time"
+)
+
+// GeneratePeriods returns the pay periods of group that start between from
+// and to inclusive, in order.
+func GeneratePeriods(group *model.PayGroup, from, to time.Time) []model.PayrollPeriod {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	var start time.Time
+	switch group.Frequency {
+	case model.PayMonthly, model.PaySemiMonthly:
+		start = time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case model.PayWeekly, model.PayBiWeekly:
+		length := periodDays(group.Frequency)
+		anchor := time.Date(group.AnchorDate.Year(), group.AnchorDate.Month(), group.AnchorDate.Day(), 0, 0, 0, 0, time.UTC)
+		offset := int(from.Sub(anchor).Hours() / 24)
+		cycles := offset / length
+		if offset < 0 && offset%length != 0 {
+			cycles-- // round towards the earlier period
+		}
+		start = anchor.AddDate(0, 0, cycles*length)
+	default:
+		return nil
+	}
+
+	var periods []model.PayrollPeriod
+	for !start.After(to) {
+		end := nextPeriodStart(group.Frequency, start).AddDate(0, 0, -1)
+		if !start.Before(from) {
+			periods = append(periods, model.PayrollPeriod{
+				PayGroupID: &group.ID,
+				StartDate:  start,
+				EndDate:    end,
+			})
+		}
+		start = end.AddDate(0, 0, 1)
+	}
+	return periods
+}
+
+func periodDays(frequency model.PayFrequency) int {
+	if frequency == model.PayBiWeekly {
+		return 14
+	}
+	return 7
+}
+
+func nextPeriodStart(frequency model.PayFrequency, start time.Time) time.Time {
+	switch frequency {
+	case model.PayMonthly:
+		return start.AddDate(0, 1, 0)
+	case model.PaySemiMonthly:
+		if start.Day() == 1 {
+			return start.AddDate(0, 0, 15)
+		}
+		return time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return start.AddDate(0, 0, periodDays(frequency))
+	}
+}
+
+// ClosesMonth reports whether monthly amounts, fixed allowances and BPJS
+// contributions, are paid in the period. Groups paid more than once a month
+// pay them once, in the period holding the month's last day.
+func (pc *PayContext) ClosesMonth() bool {
+	return closesMonth(pc.PayFrequency, pc.Period)
+}
+
+// ClosesYear reports whether the tax year is reconciled in the period: the
+// December period, or for groups paid more than once a month the one
+// holding December 31.
+func (pc *PayContext) ClosesYear() bool {
+	return closesYear(pc.PayFrequency, pc.Period)
+}
+
+func closesMonth(frequency model.PayFrequency, period *model.PayrollPeriod) bool {
+	if paidMonthly(frequency) {
+		return true
+	}
+	monthEnd := time.Date(period.StartDate.Year(), period.StartDate.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+	return !dateKeyAfter(monthEnd, period.EndDate)
+}
+
+func closesYear(frequency model.PayFrequency, period *model.PayrollPeriod) bool {
+	if paidMonthly(frequency) {
+		return period.EndDate.Month() == time.December
+	}
+	return closesMonth(frequency, period) && period.StartDate.Month() == time.December
+}
+
+// taxYear is the year whose payslips period is taxed with: the year of its
+// end date, or of its start date for groups paid more than once a month,
+// whose month is the one they start in.
+func taxYear(frequency model.PayFrequency, period *model.PayrollPeriod) int {
+	if paidMonthly(frequency) {
+		return period.EndDate.Year()
+	}
+	return period.StartDate.Year()
+}
+
+// needsYearPayslips reports whether the income tax of period reads the
+// earlier payslips of its tax year: to reconcile the year, or to withhold on
+// the month's total for groups paid more than once a month.
+func needsYearPayslips(frequency model.PayFrequency, period *model.PayrollPeriod) bool {
+	return closesYear(frequency, period) || !paidMonthly(frequency) && closesMonth(frequency, period)
+}
+
+func paidMonthly(frequency model.PayFrequency) bool {
+	return frequency == "" || frequency == model.PayMonthly
+}
+
+func sameMonth(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month()
+}
+
+func samePayGroup(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// periodFrequency is the pay frequency of period, monthly when it has no
+// pay group.
+func periodFrequency(period *model.PayrollPeriod) model.PayFrequency {
+	if period.PayGroup == nil {
+		return model.PayMonthly
+	}
+	return period.PayGroup.Frequency
+}
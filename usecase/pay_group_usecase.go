@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"payroll/domain/dto"
+	"payroll/domain/model"
+	"payroll/repositories"
+	"time"
+)
+
+func NewPayGroupUsecase(payGroupRepo repositories.PayGroupRepository, payrollRepo repositories.PayrollRepository, auditRepo repositories.AuditRepository) *PayGroupUsecase {
+	return &PayGroupUsecase{
+		payGroupRepo: payGroupRepo,
+		payrollRepo:  payrollRepo,
+		auditRepo:    auditRepo,
+	}
+}
+
+func (g *PayGroupUsecase) GetPayGroups() ([]model.PayGroup, error) {
+	return g.payGroupRepo.GetAll()
+}
+
+func (g *PayGroupUsecase) CreatePayGroup(req *dto.PayGroupRequest, userID uint, ipAddress, requestID string) (*model.PayGroup, error) {
+	group := &model.PayGroup{
+		BaseModel: model.BaseModel{
+			CreatedBy: &userID,
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		IsActive: true,
+	}
+	if err := applyPayGroup(group, req); err != nil {
+		return nil, err
+	}
+
+	if err := g.payGroupRepo.Create(group); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(group)
+	g.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "CREATE",
+		TableName: "pay_groups",
+		RecordID:  &group.ID,
+		NewData:   string(newData),
+	})
+
+	return group, nil
+}
+
+func (g *PayGroupUsecase) UpdatePayGroup(id uint, req *dto.PayGroupRequest, userID uint, ipAddress, requestID string) (*model.PayGroup, error) {
+	group, err := g.payGroupRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("pay group not found")
+	}
+	oldData, _ := json.Marshal(group)
+
+	if err := applyPayGroup(group, req); err != nil {
+		return nil, err
+	}
+	group.UpdatedBy = &userID
+	group.IPAddress = ipAddress
+	group.RequestID = requestID
+
+	if err := g.payGroupRepo.Update(group); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(group)
+	g.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "UPDATE",
+		TableName: "pay_groups",
+		RecordID:  &group.ID,
+		OldData:   string(oldData),
+		NewData:   string(newData),
+	})
+
+	return group, nil
+}
+
+func applyPayGroup(group *model.PayGroup, req *dto.PayGroupRequest) error {
+	frequency := model.PayFrequency(req.Frequency)
+	if !frequency.IsValid() {
+		return errors.New("invalid pay frequency")
+	}
+
+	var anchorDate time.Time
+	if req.AnchorDate != "" {
+		var err error
+		anchorDate, err = time.Parse("2006-01-02", req.AnchorDate)
+		if err != nil {
+			return errors.New("invalid anchor date format")
+		}
+	} else if frequency == model.PayWeekly || frequency == model.PayBiWeekly {
+		return errors.New("anchor date is required for weekly and bi-weekly pay groups")
+	}
+
+	group.Name = req.Name
+	group.Frequency = frequency
+	group.AnchorDate = anchorDate
+	if req.IsActive != nil {
+		group.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+// GeneratePeriods creates the pay group's periods that start in the
+// requested range as drafts. Periods overlapping one the group already has
+// are skipped, so generating the same range twice creates nothing new.
+func (g *PayGroupUsecase) GeneratePeriods(id uint, req *dto.PeriodGenerationRequest, userID uint, ipAddress, requestID string) ([]model.PayrollPeriod, error) {
+	group, err := g.payGroupRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("pay group not found")
+	}
+	if !group.IsActive {
+		return nil, errors.New("pay group is not active")
+	}
+
+	from, err := time.Parse("2006-01-02", req.From)
+	if err != nil {
+		return nil, errors.New("invalid from date format")
+	}
+	to, err := time.Parse("2006-01-02", req.To)
+	if err != nil {
+		return nil, errors.New("invalid to date format")
+	}
+	if to.Before(from) {
+		return nil, errors.New("to date cannot be before from date")
+	}
+	if to.After(from.AddDate(1, 0, 0)) {
+		return nil, errors.New("periods can be generated for at most a year at a time")
+	}
+
+	created := make([]model.PayrollPeriod, 0)
+	for _, period := range GeneratePeriods(group, from, to) {
+		existing, err := g.payrollRepo.GetOverlappingPeriods(period.PayGroupID, period.StartDate, period.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) > 0 {
+			continue
+		}
+
+		period.Status = model.PeriodDraft
+		period.CreatedBy = &userID
+		period.IPAddress = ipAddress
+		period.RequestID = requestID
+		if err := g.payrollRepo.CreatePeriod(&period); err != nil {
+			return nil, err
+		}
+
+		// Log audit
+		newData, _ := json.Marshal(period)
+		g.auditRepo.Create(&model.AuditLog{
+			BaseModel: model.BaseModel{
+				IPAddress: ipAddress,
+				RequestID: requestID,
+			},
+			UserID:    &userID,
+			Action:    "CREATE",
+			TableName: "payroll_periods",
+			RecordID:  &period.ID,
+			NewData:   string(newData),
+		})
+
+		created = append(created, period)
+	}
+
+	return created, nil
+}
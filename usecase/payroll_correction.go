@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"payroll/domain/dto"
+	"payroll/domain/model"
+	"payroll/repositories"
+)
+
+// CorrectPayroll runs an off-cycle correction for a processed period. The
+// selected employees are recalculated with the data as it is now, and each
+// one whose result differs from the payslips already issued gets an
+// adjustment payslip carrying only the difference, linked to the original
+// payslip. Deductions and leave payouts are not applied again. The
+// correction happens in one transaction; when any step fails nothing is
+// stored.
+func (p *PayrollUsecase) CorrectPayroll(req *dto.PayrollCorrectionRequest, userID uint, ipAddress, requestID string) ([]model.Payslip, error) {
+	period, err := p.payrollRepo.GetPeriodByID(req.PayrollPeriodID)
+	if err != nil {
+		return nil, errors.New("payroll period not found")
+	}
+	if err := checkCorrectable(period); err != nil {
+		return nil, err
+	}
+
+	var adjustments []model.Payslip
+	err = p.transactor.WithinTransaction(func(repos *repositories.Repositories) error {
+		adjustments, err = p.withRepositories(repos).correctPayroll(period.ID, req, userID, ipAddress, requestID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return adjustments, nil
+}
+
+func checkCorrectable(period *model.PayrollPeriod) error {
+	if !period.Status.Processed() {
+		return errors.New("payroll for this period has not been processed")
+	}
+	if period.Status == model.PeriodClosed {
+		return errors.New("payroll period is closed")
+	}
+	return nil
+}
+
+// correctPayroll does the work of CorrectPayroll inside its transaction.
+func (p *PayrollUsecase) correctPayroll(periodID uint, req *dto.PayrollCorrectionRequest, userID uint, ipAddress, requestID string) ([]model.Payslip, error) {
+	// Lock the period so a concurrent correction or reversal waits, then
+	// check it again
+	period, err := p.payrollRepo.LockPeriod(periodID)
+	if err != nil {
+		return nil, errors.New("payroll period not found")
+	}
+	if err := checkCorrectable(period); err != nil {
+		return nil, err
+	}
+
+	inputs, err := p.loadRunInputs(period)
+	if err != nil {
+		return nil, err
+	}
+	inputs.correction = true
+
+	// Find the employees and the payslips issued to them
+	users := make([]model.User, 0, len(req.UserIDs))
+	issued := make([][]model.Payslip, 0, len(req.UserIDs))
+	for _, employeeID := range req.UserIDs {
+		user, err := p.userRepo.GetByID(employeeID)
+		if err != nil || user.Role != "employee" {
+			return nil, fmt.Errorf("employee %d not found", employeeID)
+		}
+
+		payslips, err := p.payrollRepo.GetPayslipsByUserAndPeriod(user.ID, period.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(payslips) == 0 || payslips[0].Kind != model.PayslipRegular {
+			return nil, fmt.Errorf("employee %d has no payslip in this period", employeeID)
+		}
+		users = append(users, *user)
+		issued = append(issued, payslips)
+	}
+
+	inputs.records, err = p.loadCorrectionRecords(period, users)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate every adjustment before storing any
+	adjustments := make([]model.Payslip, 0, len(users))
+	for i := range users {
+		user := &users[i]
+		recalculated, err := p.calculatePayslip(user, inputs)
+		if err != nil {
+			return nil, fmt.Errorf("employee %d: %w", user.ID, err)
+		}
+
+		adjustment := AdjustmentPayslip(recalculated, issued[i])
+		if adjustment == nil {
+			continue // Nothing changed for this employee
+		}
+		adjustment.CreatedBy = &userID
+		adjustment.IPAddress = ipAddress
+		adjustment.RequestID = requestID
+		adjustment.CorrectionReason = req.Reason
+		adjustments = append(adjustments, *adjustment)
+	}
+
+	for i := range adjustments {
+		adjustment := &adjustments[i]
+		if err := p.payrollRepo.CreatePayslip(adjustment); err != nil {
+			return nil, err
+		}
+
+		// Link records the regular run did not pay
+		if err := p.assignRecords(adjustment); err != nil {
+			return nil, err
+		}
+
+		// Log audit
+		newData, _ := json.Marshal(adjustment)
+		if err := p.auditRepo.Create(&model.AuditLog{
+			BaseModel: model.BaseModel{
+				IPAddress: ipAddress,
+				RequestID: requestID,
+			},
+			UserID:    &userID,
+			Action:    "CREATE",
+			TableName: "payslips",
+			RecordID:  &adjustment.ID,
+			NewData:   string(newData),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.markRecordsProcessed(period.ID); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(map[string]interface{}{
+		"payroll_period_id": period.ID,
+		"user_ids":          req.UserIDs,
+		"reason":            req.Reason,
+		"adjustments":       len(adjustments),
+	})
+	if err := p.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "PAYROLL_CORRECTED",
+		TableName: "payroll_periods",
+		RecordID:  &period.ID,
+		NewData:   string(newData),
+	}); err != nil {
+		return nil, err
+	}
+
+	return adjustments, nil
+}
+
+// AdjustmentPayslip compares a recalculated payslip with the payslips issued
+// so far (the regular one first) and returns a payslip holding the line by
+// line difference, or nil when there is none. Deduction, leave payout and
+// retro lines are left out: a correction does not calculate them again. The
+// adjustment names the records the issued payslips had not paid.
+func AdjustmentPayslip(recalculated *model.Payslip, issued []model.Payslip) *model.Payslip {
+	type lineKey struct {
+		code     string
+		label    string
+		itemType model.PayslipItemType
+	}
+
+	var lines []model.PayslipItem
+	index := make(map[lineKey]int)
+	add := func(item model.PayslipItem, sign model.Money) {
+		if item.Code == model.PayCodeDeduction || item.Code == model.PayCodeLeavePayout || item.Code == model.PayCodeRetro {
+			return
+		}
+		key := lineKey{item.Code, item.Label, item.Type}
+		i, ok := index[key]
+		if !ok {
+			i = len(lines)
+			index[key] = i
+			lines = append(lines, model.PayslipItem{
+				Code:          item.Code,
+				Label:         item.Label,
+				Type:          item.Type,
+				Rate:          item.Rate,
+				Taxable:       item.Taxable,
+				TaxDeductible: item.TaxDeductible,
+			})
+		}
+		lines[i].Quantity += float64(sign) * item.Quantity
+		lines[i].Amount += sign * item.Amount
+	}
+
+	for _, item := range recalculated.Items {
+		add(item, 1)
+	}
+	var attendanceDays, paidLeaveDays, lateMinutes, earlyLeaveMinutes int
+	var overtimeHours float64
+	type recordKey struct {
+		recordType model.PayslipRecordType
+		id         uint
+	}
+	paid := make(map[recordKey]bool)
+	for _, payslip := range issued {
+		for _, item := range payslip.Items {
+			add(item, -1)
+		}
+		for _, record := range payslip.Records {
+			paid[recordKey{record.RecordType, record.RecordID}] = true
+		}
+		attendanceDays += payslip.AttendanceDays
+		paidLeaveDays += payslip.PaidLeaveDays
+		lateMinutes += payslip.LateMinutes
+		earlyLeaveMinutes += payslip.EarlyLeaveMinutes
+		overtimeHours += payslip.OvertimeHours
+	}
+
+	items := make([]model.PayslipItem, 0, len(lines))
+	for _, line := range lines {
+		if line.Amount != 0 {
+			items = append(items, line)
+		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	// Records the issued payslips did not pay yet
+	records := make([]model.PayslipRecord, 0)
+	for _, record := range recalculated.Records {
+		if !paid[recordKey{record.RecordType, record.RecordID}] {
+			records = append(records, record)
+		}
+	}
+
+	pc := &PayContext{Items: items}
+	original := issued[0]
+	return &model.Payslip{
+		UserID:             recalculated.UserID,
+		PayrollPeriodID:    recalculated.PayrollPeriodID,
+		Kind:               model.PayslipAdjustment,
+		OriginalPayslipID:  &original.ID,
+		Status:             model.PayslipActive,
+		BaseSalary:         recalculated.BaseSalary,
+		WorkingDays:        recalculated.WorkingDays,
+		AttendanceDays:     recalculated.AttendanceDays - attendanceDays,
+		PaidLeaveDays:      recalculated.PaidLeaveDays - paidLeaveDays,
+		ProrationPolicy:    recalculated.ProrationPolicy,
+		LateMinutes:        recalculated.LateMinutes - lateMinutes,
+		EarlyLeaveMinutes:  recalculated.EarlyLeaveMinutes - earlyLeaveMinutes,
+		OvertimeHours:      recalculated.OvertimeHours - overtimeHours,
+		OvertimePay:        pc.SumByCode(model.PayCodeOvertime),
+		ReimbursementTotal: pc.SumByCode(model.PayCodeReimbursement),
+		TotalEarnings:      pc.Total(model.PayslipItemEarning),
+		TotalDeductions:    pc.Total(model.PayslipItemDeduction),
+		TaxableIncome:      pc.TaxableIncome(),
+		TaxDeductible:      pc.TaxDeductible(),
+		TaxAmount:          incomeTaxWithheld(items),
+		EmployerCost:       pc.Total(model.PayslipItemEmployerCost),
+		TotalPay:           pc.NetPay(),
+		Items:              items,
+		Records:            records,
+		User:               recalculated.User,
+	}
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"payroll/domain/dto"
+	"payroll/domain/model"
+	"strings"
+	"time"
+)
+
+// GetPayrollPeriods lists payroll periods, optionally of one pay group or
+// in one status.
+func (p *PayrollUsecase) GetPayrollPeriods(payGroupID uint, status string) ([]model.PayrollPeriod, error) {
+	if status != "" && !model.PeriodStatus(status).IsValid() {
+		return nil, errors.New("invalid period status")
+	}
+	return p.payrollRepo.GetPeriods(payGroupID, model.PeriodStatus(status))
+}
+
+// TransitionPayrollPeriod moves a period to another status by hand. Moving
+// to processed or paid, and back from processed, is what running, marking
+// paid and reversing payroll do, so those are refused here.
+func (p *PayrollUsecase) TransitionPayrollPeriod(id uint, req *dto.PayrollPeriodTransitionRequest, userID uint, ipAddress, requestID string) (*model.PayrollPeriod, error) {
+	period, err := p.payrollRepo.GetPeriodByID(id)
+	if err != nil {
+		return nil, errors.New("payroll period not found")
+	}
+
+	status := model.PeriodStatus(req.Status)
+	switch {
+	case !status.IsValid():
+		return nil, errors.New("invalid period status")
+	case status == model.PeriodProcessed:
+		return nil, errors.New("run payroll to process a period")
+	case status == model.PeriodPaid:
+		return nil, errors.New("mark payroll paid to pay a period")
+	case period.Status == model.PeriodProcessed:
+		return nil, errors.New("reverse payroll to reopen a processed period")
+	case !period.Status.CanTransitionTo(status):
+		return nil, fmt.Errorf("cannot move a %s period to %s", period.Status, status)
+	}
+
+	oldData, _ := json.Marshal(period)
+	period.SetStatus(status, time.Now())
+	period.UpdatedBy = &userID
+	period.IPAddress = ipAddress
+	period.RequestID = requestID
+
+	if err := p.payrollRepo.UpdatePeriod(period); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(period)
+	p.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "PERIOD_" + strings.ToUpper(string(status)),
+		TableName: "payroll_periods",
+		RecordID:  &period.ID,
+		OldData:   string(oldData),
+		NewData:   string(newData),
+	})
+
+	return period, nil
+}
@@ -3,9 +3,13 @@ package usecase
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"payroll/domain/dto"
 	"payroll/domain/model"
 	"payroll/repositories"
+	"runtime"
+	"sort"
+	"sync"
 	"time"
 )
 
@@ -15,7 +19,15 @@ func NewPayrollUsecase(
 	attendanceRepo repositories.AttendanceRepository,
 	overtimeRepo repositories.OvertimeRepository,
 	reimbursementRepo repositories.ReimbursementRepository,
+	holidayRepo repositories.HolidayRepository,
+	taxRepo repositories.TaxRepository,
+	deductionRepo repositories.DeductionRepository,
+	allowanceRepo repositories.AllowanceRepository,
+	compensationRepo repositories.CompensationRepository,
+	scheduleRepo repositories.ScheduleRepository,
+	leaveRepo repositories.LeaveRepository,
 	auditRepo repositories.AuditRepository,
+	transactor repositories.Transactor,
 ) *PayrollUsecase {
 	return &PayrollUsecase{
 		payrollRepo:       payrollRepo,
@@ -23,10 +35,36 @@ func NewPayrollUsecase(
 		attendanceRepo:    attendanceRepo,
 		overtimeRepo:      overtimeRepo,
 		reimbursementRepo: reimbursementRepo,
+		holidayRepo:       holidayRepo,
+		taxRepo:           taxRepo,
+		deductionRepo:     deductionRepo,
+		allowanceRepo:     allowanceRepo,
+		compensationRepo:  compensationRepo,
+		scheduleRepo:      scheduleRepo,
+		leaveRepo:         leaveRepo,
 		auditRepo:         auditRepo,
+		transactor:        transactor,
+		components:        DefaultPayComponents(),
+		workers:           defaultPayrollWorkers,
 	}
 }
 
+// defaultPayrollWorkers is how many payslips a run calculates at once. A
+// run loads its inputs before calculating, so calculating is bound by the
+// CPUs; only the few payslips owed retro or leave pay query on their own.
+var defaultPayrollWorkers = runtime.GOMAXPROCS(0)
+
+// SetWorkers sets how many payslips a run calculates at once.
+func (p *PayrollUsecase) SetWorkers(workers int) {
+	p.workers = max(workers, 1)
+}
+
+// RegisterPayComponent appends a component to the ones evaluated for every
+// payslip. Components registered later see the items of earlier ones.
+func (p *PayrollUsecase) RegisterPayComponent(component PayComponent) {
+	p.components = append(p.components, component)
+}
+
 func (p *PayrollUsecase) CreatePayrollPeriod(req *dto.PayrollPeriodRequest, userID uint, ipAddress, requestID string) (*model.PayrollPeriod, error) {
 	startDate, err := time.Parse("2006-01-02", req.StartDate)
 	if err != nil {
@@ -42,6 +80,16 @@ func (p *PayrollUsecase) CreatePayrollPeriod(req *dto.PayrollPeriodRequest, user
 		return nil, errors.New("end date cannot be before start date")
 	}
 
+	// A day may only be paid by one period
+	overlapping, err := p.payrollRepo.GetOverlappingPeriods(nil, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if len(overlapping) > 0 {
+		return nil, fmt.Errorf("period overlaps payroll period %d (%s to %s)", overlapping[0].ID,
+			overlapping[0].StartDate.Format("2006-01-02"), overlapping[0].EndDate.Format("2006-01-02"))
+	}
+
 	period := &model.PayrollPeriod{
 		BaseModel: model.BaseModel{
 			CreatedBy: &userID,
@@ -50,6 +98,7 @@ func (p *PayrollUsecase) CreatePayrollPeriod(req *dto.PayrollPeriodRequest, user
 		},
 		StartDate: startDate,
 		EndDate:   endDate,
+		Status:    model.PeriodOpen,
 	}
 
 	if err := p.payrollRepo.CreatePeriod(period); err != nil {
@@ -73,44 +122,123 @@ func (p *PayrollUsecase) CreatePayrollPeriod(req *dto.PayrollPeriodRequest, user
 	return period, nil
 }
 
-func (p *PayrollUsecase) RunPayroll(req *dto.PayrollRunRequest, userID uint, ipAddress, requestID string) error {
+// RunPayroll calculates and stores the payslips of every employee paid in
+// the period, all or nothing: when any employee cannot be calculated or
+// stored, nothing is stored and the error is a *PayrollRunError carrying
+// the report of what failed.
+func (p *PayrollUsecase) RunPayroll(req *dto.PayrollRunRequest, userID uint, ipAddress, requestID string) (*dto.PayrollRunReport, error) {
 	// Get payroll period
 	period, err := p.payrollRepo.GetPeriodByID(req.PayrollPeriodID)
 	if err != nil {
-		return errors.New("payroll period not found")
+		return nil, errors.New("payroll period not found")
+	}
+	if err := checkRunnable(period); err != nil {
+		return nil, err
+	}
+
+	payslips, failures, err := p.computePayroll(period)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.PayrollRunReport{
+		PayrollPeriodID: period.ID,
+		EmployeeCount:   len(payslips) + len(failures),
+		Failures:        failures,
+	}
+	if len(failures) > 0 {
+		return report, &PayrollRunError{Report: report}
+	}
+
+	err = p.transactor.WithinTransaction(func(repos *repositories.Repositories) error {
+		return p.withRepositories(repos).storePayroll(period.ID, payslips, report, userID, ipAddress, requestID)
+	})
+	if err != nil {
+		if len(report.Failures) > 0 {
+			return report, &PayrollRunError{Report: report}
+		}
+		return nil, err
 	}
 
-	if period.IsProcessed {
+	report.Processed = len(payslips)
+	return report, nil
+}
+
+// PayrollRunError is returned when a payroll run stored nothing because
+// some employees failed.
+type PayrollRunError struct {
+	Report *dto.PayrollRunReport
+}
+
+func (e *PayrollRunError) Error() string {
+	return fmt.Sprintf("payroll failed for %d of %d employees; nothing was stored", len(e.Report.Failures), e.Report.EmployeeCount)
+}
+
+func checkRunnable(period *model.PayrollPeriod) error {
+	if period.Status.Processed() {
 		return errors.New("payroll for this period has already been processed")
 	}
+	if !period.Status.CanTransitionTo(model.PeriodProcessed) {
+		return fmt.Errorf("payroll cannot run for a %s period", period.Status)
+	}
+	return nil
+}
 
-	// Get all employees
-	users, err := p.userRepo.GetAll()
+// storePayroll stores the payslips of a run, settles what they took and
+// marks the period processed. It runs inside the run's transaction and
+// stops at the first error; the employees whose payslips, deductions or
+// leave cannot be stored are added to the report.
+func (p *PayrollUsecase) storePayroll(periodID uint, payslips []model.Payslip, report *dto.PayrollRunReport, userID uint, ipAddress, requestID string) error {
+	// Lock the period so a concurrent run waits, then check it again
+	period, err := p.payrollRepo.LockPeriod(periodID)
 	if err != nil {
+		return errors.New("payroll period not found")
+	}
+	if err := checkRunnable(period); err != nil {
 		return err
 	}
 
-	// Process payroll for each employee
-	for _, user := range users {
-		if user.Role != "employee" {
-			continue
+	// Save the leave balances paid out first; the payout lines point at
+	// their IDs
+	for i := range payslips {
+		if err := saveLeaveBalances(p.leaveRepo, payslips[i].LeaveBalances...); err != nil {
+			report.Failures = append(report.Failures, storeFailure(&payslips[i], err))
+			return err
 		}
+	}
 
-		payslip, err := p.calculatePayslip(&user, period)
-		if err != nil {
-			continue // Skip this employee if error
+	// Create payslips and link the records they paid to the period
+	if err := p.payrollRepo.CreatePayslips(payslips); err != nil {
+		// The transaction is aborted, so the payslips of the failed batch
+		// cannot be told apart; report them all
+		var batchErr *repositories.PayslipBatchError
+		if errors.As(err, &batchErr) {
+			for i := batchErr.Start; i < batchErr.End; i++ {
+				report.Failures = append(report.Failures, storeFailure(&payslips[i], batchErr.Err))
+			}
 		}
+		return err
+	}
+	if err := p.assignPayslipRecords(period.ID, payslips); err != nil {
+		return err
+	}
+
+	for i := range payslips {
+		payslip := &payslips[i]
 
-		// Create payslip
-		if err := p.payrollRepo.CreatePayslip(payslip); err != nil {
-			continue // Skip if error creating payslip
+		// Update balances of the deductions and leave the payslip took
+		err := p.settleDeductions(payslip)
+		if err == nil {
+			err = p.settleLeavePayouts(payslip)
+		}
+		if err != nil {
+			report.Failures = append(report.Failures, storeFailure(payslip, err))
+			return err
 		}
 	}
 
 	// Mark period as processed
-	now := time.Now()
-	period.IsProcessed = true
-	period.ProcessedAt = &now
+	period.SetStatus(model.PeriodProcessed, time.Now())
 	period.UpdatedBy = &userID
 	period.IPAddress = ipAddress
 	period.RequestID = requestID
@@ -119,14 +247,14 @@ func (p *PayrollUsecase) RunPayroll(req *dto.PayrollRunRequest, userID uint, ipA
 		return err
 	}
 
-	// Mark all records as processed
-	_ = p.attendanceRepo.MarkAsProcessed(period.ID)
-	_ = p.overtimeRepo.MarkAsProcessed(period.ID)
-	_ = p.reimbursementRepo.MarkAsProcessed(period.ID)
+	// Mark all records linked to the period as processed
+	if err := p.markRecordsProcessed(period.ID); err != nil {
+		return err
+	}
 
 	// Log audit
 	newData, _ := json.Marshal(period)
-	p.auditRepo.Create(&model.AuditLog{
+	return p.auditRepo.Create(&model.AuditLog{
 		BaseModel: model.BaseModel{
 			IPAddress: ipAddress,
 			RequestID: requestID,
@@ -137,34 +265,649 @@ func (p *PayrollUsecase) RunPayroll(req *dto.PayrollRunRequest, userID uint, ipA
 		RecordID:  &period.ID,
 		NewData:   string(newData),
 	})
+}
+
+func storeFailure(payslip *model.Payslip, err error) dto.PayrollRunFailure {
+	return dto.PayrollRunFailure{
+		UserID:   payslip.UserID,
+		Username: payslip.User.Username,
+		Stage:    "store",
+		Error:    err.Error(),
+	}
+}
+
+// withRepositories returns a copy of the usecase that works through repos,
+// such as the repositories of a transaction.
+func (p *PayrollUsecase) withRepositories(repos *repositories.Repositories) *PayrollUsecase {
+	tx := *p
+	tx.payrollRepo = repos.Payroll
+	tx.userRepo = repos.User
+	tx.attendanceRepo = repos.Attendance
+	tx.overtimeRepo = repos.Overtime
+	tx.reimbursementRepo = repos.Reimbursement
+	tx.holidayRepo = repos.Holiday
+	tx.taxRepo = repos.Tax
+	tx.deductionRepo = repos.Deduction
+	tx.allowanceRepo = repos.Allowance
+	tx.compensationRepo = repos.Compensation
+	tx.scheduleRepo = repos.Schedule
+	tx.leaveRepo = repos.Leave
+	tx.auditRepo = repos.Audit
+	return &tx
+}
+
+// ReversePayroll undoes a payroll run that has not been paid yet: the
+// period's payslips are voided and kept for history, the deductions they
+// took are restored, the consumed records are unmarked and the period is
+// reopened so payroll can run again. Only the latest run of a pay group can
+// be reversed. The reversal happens in one transaction; when any step fails
+// nothing is undone.
+func (p *PayrollUsecase) ReversePayroll(req *dto.PayrollRunRequest, userID uint, ipAddress, requestID string) error {
+	period, err := p.payrollRepo.GetPeriodByID(req.PayrollPeriodID)
+	if err != nil {
+		return errors.New("payroll period not found")
+	}
+	if err := checkReversible(period); err != nil {
+		return err
+	}
+
+	return p.transactor.WithinTransaction(func(repos *repositories.Repositories) error {
+		return p.withRepositories(repos).reversePayroll(period.ID, userID, ipAddress, requestID)
+	})
+}
 
+func checkReversible(period *model.PayrollPeriod) error {
+	if !period.Status.Processed() {
+		return errors.New("payroll for this period has not been processed")
+	}
+	if period.Status != model.PeriodProcessed {
+		return errors.New("payroll for this period has already been paid")
+	}
 	return nil
 }
 
-func (p *PayrollUsecase) calculatePayslip(user *model.User, period *model.PayrollPeriod) (*model.Payslip, error) {
-	// Calculate working days in period
-	workingDays := p.calculateWorkingDays(period.StartDate, period.EndDate)
+// reversePayroll does the work of ReversePayroll inside its transaction.
+func (p *PayrollUsecase) reversePayroll(periodID uint, userID uint, ipAddress, requestID string) error {
+	// Lock the period so a concurrent run or reversal waits, then check it
+	// again
+	period, err := p.payrollRepo.LockPeriod(periodID)
+	if err != nil {
+		return errors.New("payroll period not found")
+	}
+	if err := checkReversible(period); err != nil {
+		return err
+	}
+
+	// Deductions and leave are restored to before this run, so no later run
+	// may have moved them since
+	later, err := p.payrollRepo.GetProcessedPeriodsAfter(period.PayGroupID, period.StartDate)
+	if err != nil {
+		return err
+	}
+	if len(later) > 0 {
+		return fmt.Errorf("payroll for the period starting %s has been processed; reverse it first", later[len(later)-1].StartDate.Format("2006-01-02"))
+	}
+
+	payslips, err := p.payrollRepo.GetPayslipsByPeriod(period.ID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range payslips {
+		payslip := &payslips[i]
+		oldData, _ := json.Marshal(payslip)
+
+		payslip.Status = model.PayslipVoided
+		payslip.VoidedAt = &now
+		payslip.UpdatedBy = &userID
+		payslip.IPAddress = ipAddress
+		payslip.RequestID = requestID
+		if err := p.payrollRepo.UpdatePayslip(payslip); err != nil {
+			return err
+		}
+
+		// Give back what the payslip deducted and paid out
+		if err := p.revertDeductions(payslip); err != nil {
+			return err
+		}
+		if err := p.revertLeavePayouts(payslip); err != nil {
+			return err
+		}
+
+		// Log audit
+		newData, _ := json.Marshal(payslip)
+		if err := p.auditRepo.Create(&model.AuditLog{
+			BaseModel: model.BaseModel{
+				IPAddress: ipAddress,
+				RequestID: requestID,
+			},
+			UserID:    &userID,
+			Action:    "VOID",
+			TableName: "payslips",
+			RecordID:  &payslip.ID,
+			OldData:   string(oldData),
+			NewData:   string(newData),
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Unmark all records so the next run picks them up again
+	if err := p.attendanceRepo.UnmarkProcessed(period.ID); err != nil {
+		return err
+	}
+	if err := p.overtimeRepo.UnmarkProcessed(period.ID); err != nil {
+		return err
+	}
+	if err := p.reimbursementRepo.UnmarkProcessed(period.ID); err != nil {
+		return err
+	}
+
+	// Reopen period
+	oldData, _ := json.Marshal(period)
+	period.SetStatus(model.PeriodOpen, now)
+	period.UpdatedBy = &userID
+	period.IPAddress = ipAddress
+	period.RequestID = requestID
+
+	if err := p.payrollRepo.UpdatePeriod(period); err != nil {
+		return err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(period)
+	return p.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "PAYROLL_REVERSED",
+		TableName: "payroll_periods",
+		RecordID:  &period.ID,
+		OldData:   string(oldData),
+		NewData:   string(newData),
+	})
+}
+
+// MarkPayrollPaid records that the payslips of a processed period were paid
+// out. A paid period can no longer be reversed.
+func (p *PayrollUsecase) MarkPayrollPaid(req *dto.PayrollRunRequest, userID uint, ipAddress, requestID string) (*model.PayrollPeriod, error) {
+	period, err := p.payrollRepo.GetPeriodByID(req.PayrollPeriodID)
+	if err != nil {
+		return nil, errors.New("payroll period not found")
+	}
+
+	if !period.Status.Processed() {
+		return nil, errors.New("payroll for this period has not been processed")
+	}
+	if period.Status != model.PeriodProcessed {
+		return nil, errors.New("payroll for this period has already been paid")
+	}
+
+	oldData, _ := json.Marshal(period)
+	period.SetStatus(model.PeriodPaid, time.Now())
+	period.UpdatedBy = &userID
+	period.IPAddress = ipAddress
+	period.RequestID = requestID
+
+	if err := p.payrollRepo.UpdatePeriod(period); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(period)
+	p.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "PAYROLL_PAID",
+		TableName: "payroll_periods",
+		RecordID:  &period.ID,
+		OldData:   string(oldData),
+		NewData:   string(newData),
+	})
+
+	return period, nil
+}
 
-	// Get attendance records
-	attendances, err := p.attendanceRepo.GetByUserAndPeriod(user.ID, period.StartDate, period.EndDate)
+// PreviewPayroll runs the same calculation as RunPayroll and returns the
+// payslips and totals it would produce, without storing anything, marking
+// records processed or moving deduction balances.
+func (p *PayrollUsecase) PreviewPayroll(req *dto.PayrollRunRequest) (*dto.PayrollSummaryResponse, error) {
+	period, err := p.payrollRepo.GetPeriodByID(req.PayrollPeriodID)
+	if err != nil {
+		return nil, errors.New("payroll period not found")
+	}
+
+	if period.Status.Processed() {
+		return nil, errors.New("payroll for this period has already been processed")
+	}
+
+	payslips, failures, err := p.computePayroll(period)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get overtime records
-	overtimes, err := p.overtimeRepo.GetByUserAndPeriod(user.ID, period.StartDate, period.EndDate)
+	summary := summarizePayslips(period, payslips)
+	summary.Preview = true
+	summary.Failures = failures
+	return summary, nil
+}
+
+// computePayroll calculates the payslip of every employee for period without
+// storing anything. Employees whose payslip cannot be calculated are
+// reported as failures. It must not write: RunPayroll calls it outside its
+// transaction and PreviewPayroll only reports. What a payslip has to save is
+// carried on it for storePayroll.
+func (p *PayrollUsecase) computePayroll(period *model.PayrollPeriod) ([]model.Payslip, []dto.PayrollRunFailure, error) {
+	// Get all employees
+	users, err := p.userRepo.GetAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	employees := make([]model.User, 0, len(users))
+	for _, user := range users {
+		if isPayable(&user, period) {
+			employees = append(employees, user)
+		}
+	}
+
+	inputs, err := p.loadRunInputs(period)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Load the records and pay inputs of every employee at once
+	inputs.records, err = p.loadPeriodRecords(period)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Calculate payroll for each employee, a few at a time
+	results := make([]payslipResult, len(employees))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(p.workers, len(employees)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = p.safeCalculatePayslip(&employees[i], inputs)
+			}
+		}()
+	}
+	for i := range employees {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	payslips := make([]model.Payslip, 0, len(employees))
+	var failures []dto.PayrollRunFailure
+	for i, result := range results {
+		if result.err != nil {
+			failures = append(failures, dto.PayrollRunFailure{
+				UserID:   employees[i].ID,
+				Username: employees[i].Username,
+				Stage:    "calculate",
+				Error:    result.err.Error(),
+			})
+			continue
+		}
+		payslips = append(payslips, *result.payslip)
+	}
+
+	return payslips, failures, nil
+}
+
+type payslipResult struct {
+	payslip *model.Payslip
+	err     error
+}
+
+// safeCalculatePayslip is calculatePayslip for a worker of computePayroll: a
+// component panicking fails the employee instead of the server.
+func (p *PayrollUsecase) safeCalculatePayslip(user *model.User, inputs *payrollRunInputs) (result payslipResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = payslipResult{err: fmt.Errorf("panic: %v", r)}
+		}
+	}()
+	payslip, err := p.calculatePayslip(user, inputs)
+	return payslipResult{payslip: payslip, err: err}
+}
+
+// isPayable reports whether user is paid in period: employees of the
+// period's pay group who are not on leave and were employed on at least one
+// day of it. Periods without a pay group pay the employees without one.
+func isPayable(user *model.User, period *model.PayrollPeriod) bool {
+	if user.Role != "employee" || user.EmploymentStatus == model.EmploymentOnLeave {
+		return false
+	}
+	if !samePayGroup(user.PayGroupID, period.PayGroupID) {
+		return false
+	}
+	_, _, employed := user.EmploymentWindow(period.StartDate, period.EndDate)
+	return employed
+}
+
+// payrollRunInputs holds the data shared by every payslip of one run.
+type payrollRunInputs struct {
+	period   *model.PayrollPeriod
+	holidays HolidayCalendar
+	taxTable *model.TaxTable
+	// correction runs leave deductions alone; the original run settled them.
+	correction bool
+	// records holds the records and pay inputs of the employees paid.
+	records *periodRecords
+}
+
+// periodRecords holds the records a run may pay and the inputs its
+// payslips are calculated from, grouped by employee.
+type periodRecords struct {
+	attendances         map[uint][]model.Attendance
+	leaves              map[uint][]model.LeaveRequest
+	overtimes           map[uint][]model.Overtime
+	reimbursements      map[uint][]model.Reimbursement
+	scheduleAssignments map[uint][]model.ScheduleAssignment
+	compensations       map[uint][]model.Compensation
+	userAllowances      map[uint][]model.AllowanceAssignment
+	gradeAllowances     map[string][]model.AllowanceAssignment
+	deductions          map[uint][]model.Deduction
+	// yearPayslips holds the payslips of the tax year when the period's
+	// income tax needs them.
+	yearPayslips map[uint][]model.Payslip
+	// earlierPayslips holds the payslips of earlier periods retro pay may
+	// concern, latest first.
+	earlierPayslips map[uint][]model.Payslip
+}
+
+// allowances returns the assignments of user and of their grade, ordered by
+// allowance code like AllowanceRepository.GetAssignmentsForUser.
+func (r *periodRecords) allowances(user *model.User) []model.AllowanceAssignment {
+	assignments := append([]model.AllowanceAssignment(nil), r.userAllowances[user.ID]...)
+	if user.Grade != "" {
+		assignments = append(assignments, r.gradeAllowances[user.Grade]...)
+	}
+	sort.SliceStable(assignments, func(i, j int) bool {
+		return assignments[i].Allowance.Code < assignments[j].Allowance.Code
+	})
+	return assignments
+}
+
+func newPeriodRecords() *periodRecords {
+	return &periodRecords{
+		attendances:         make(map[uint][]model.Attendance),
+		leaves:              make(map[uint][]model.LeaveRequest),
+		overtimes:           make(map[uint][]model.Overtime),
+		reimbursements:      make(map[uint][]model.Reimbursement),
+		scheduleAssignments: make(map[uint][]model.ScheduleAssignment),
+		compensations:       make(map[uint][]model.Compensation),
+		userAllowances:      make(map[uint][]model.AllowanceAssignment),
+		gradeAllowances:     make(map[string][]model.AllowanceAssignment),
+		deductions:          make(map[uint][]model.Deduction),
+		yearPayslips:        make(map[uint][]model.Payslip),
+		earlierPayslips:     make(map[uint][]model.Payslip),
+	}
+}
+
+// loadPeriodRecords loads the records period may pay and the pay inputs of
+// every employee in one query per kind of record or input.
+func (p *PayrollUsecase) loadPeriodRecords(period *model.PayrollPeriod) (*periodRecords, error) {
+	attendances, err := p.attendanceRepo.GetPayableByPeriod(period.ID, period.StartDate, period.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	leaves, err := p.leaveRepo.GetApprovedByPeriod(period.StartDate, period.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	overtimes, err := p.overtimeRepo.GetPayableByPeriod(period.ID, period.StartDate, period.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	reimbursements, err := p.reimbursementRepo.GetPayableByPeriod(period.ID, period.StartDate, period.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	scheduleAssignments, err := p.scheduleRepo.GetAssignments()
+	if err != nil {
+		return nil, err
+	}
+	compensations, err := p.compensationRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	allowances, err := p.allowanceRepo.GetActiveAssignments()
+	if err != nil {
+		return nil, err
+	}
+	deductions, err := p.deductionRepo.GetActive(period.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	var yearPayslips []model.Payslip
+	if frequency := periodFrequency(period); needsYearPayslips(frequency, period) {
+		yearPayslips, err = p.payrollRepo.GetPayslipsByYear(taxYear(frequency, period))
+		if err != nil {
+			return nil, err
+		}
+	}
+	earlierPayslips, err := p.payrollRepo.GetRetroPayslips(period.StartDate)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get reimbursement records
-	reimbursements, err := p.reimbursementRepo.GetByUserAndPeriod(user.ID, period.StartDate, period.EndDate)
+	records := newPeriodRecords()
+	for _, attendance := range attendances {
+		records.attendances[attendance.UserID] = append(records.attendances[attendance.UserID], attendance)
+	}
+	for _, leave := range leaves {
+		records.leaves[leave.UserID] = append(records.leaves[leave.UserID], leave)
+	}
+	for _, overtime := range overtimes {
+		records.overtimes[overtime.UserID] = append(records.overtimes[overtime.UserID], overtime)
+	}
+	for _, reimbursement := range reimbursements {
+		records.reimbursements[reimbursement.UserID] = append(records.reimbursements[reimbursement.UserID], reimbursement)
+	}
+	for _, assignment := range scheduleAssignments {
+		records.scheduleAssignments[assignment.UserID] = append(records.scheduleAssignments[assignment.UserID], assignment)
+	}
+	for _, compensation := range compensations {
+		records.compensations[compensation.UserID] = append(records.compensations[compensation.UserID], compensation)
+	}
+	for _, assignment := range allowances {
+		if assignment.UserID != nil {
+			records.userAllowances[*assignment.UserID] = append(records.userAllowances[*assignment.UserID], assignment)
+		} else if assignment.Grade != "" {
+			records.gradeAllowances[assignment.Grade] = append(records.gradeAllowances[assignment.Grade], assignment)
+		}
+	}
+	for _, deduction := range deductions {
+		records.deductions[deduction.UserID] = append(records.deductions[deduction.UserID], deduction)
+	}
+	for _, payslip := range yearPayslips {
+		records.yearPayslips[payslip.UserID] = append(records.yearPayslips[payslip.UserID], payslip)
+	}
+	for _, payslip := range earlierPayslips {
+		records.earlierPayslips[payslip.UserID] = append(records.earlierPayslips[payslip.UserID], payslip)
+	}
+	return records, nil
+}
+
+// loadCorrectionRecords loads the records period may pay and the pay inputs
+// a correction recalculates users with, one employee at a time. Deductions,
+// leave payouts and retro pay are not calculated again, so their inputs
+// are left out.
+func (p *PayrollUsecase) loadCorrectionRecords(period *model.PayrollPeriod, users []model.User) (*periodRecords, error) {
+	frequency := periodFrequency(period)
+	records := newPeriodRecords()
+	for _, user := range users {
+		scheduleAssignments, err := p.scheduleRepo.GetAssignmentsByUser(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		attendances, err := p.attendanceRepo.GetPayableByUserAndPeriod(user.ID, period.ID, period.StartDate, period.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		leaves, err := p.leaveRepo.GetApprovedByUserAndPeriod(user.ID, period.StartDate, period.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		overtimes, err := p.overtimeRepo.GetPayableByUserAndPeriod(user.ID, period.ID, period.StartDate, period.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		reimbursements, err := p.reimbursementRepo.GetPayableByUserAndPeriod(user.ID, period.ID, period.StartDate, period.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		compensations, err := p.compensationRepo.GetByUser(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		allowances, err := p.allowanceRepo.GetAssignmentsForUser(user.ID, user.Grade)
+		if err != nil {
+			return nil, err
+		}
+		if needsYearPayslips(frequency, period) {
+			records.yearPayslips[user.ID], err = p.payrollRepo.GetUserPayslipsByYear(user.ID, taxYear(frequency, period))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		records.scheduleAssignments[user.ID] = scheduleAssignments
+		records.attendances[user.ID] = attendances
+		records.leaves[user.ID] = leaves
+		records.overtimes[user.ID] = overtimes
+		records.reimbursements[user.ID] = reimbursements
+		records.compensations[user.ID] = compensations
+		// Grade assignments come with the user's; allowances still resolves
+		// them by code
+		records.userAllowances[user.ID] = allowances
+	}
+	return records, nil
+}
+
+func (p *PayrollUsecase) loadRunInputs(period *model.PayrollPeriod) (*payrollRunInputs, error) {
+	// Get holidays in period
+	holidays, err := p.holidayRepo.GetByRange(period.StartDate, period.EndDate)
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate attendance days
-	attendanceDays := len(attendances)
+	// Get income tax rates in force
+	taxTable, err := p.taxRepo.GetTable(period.EndDate.Year())
+	if err != nil {
+		return nil, fmt.Errorf("no income tax table for %d", period.EndDate.Year())
+	}
+
+	return &payrollRunInputs{
+		period:   period,
+		holidays: NewHolidayCalendar(holidays),
+		taxTable: taxTable,
+	}, nil
+}
+
+func (p *PayrollUsecase) calculatePayslip(user *model.User, inputs *payrollRunInputs) (*model.Payslip, error) {
+	period := inputs.period
+	records := inputs.records
+
+	// Get work schedule
+	schedule := NewEmployeeSchedule(records.scheduleAssignments[user.ID])
+
+	// Calculate working days in period
+	workingDays := p.calculateWorkingDays(period.StartDate, period.EndDate, schedule, inputs.holidays)
+
+	// Get attendance, leave, overtime and reimbursement records no other
+	// period paid
+	attendances := records.attendances[user.ID]
+	leaves := records.leaves[user.ID]
+	overtimes := records.overtimes[user.ID]
+	reimbursements := records.reimbursements[user.ID]
+
+	// Get earlier payslips of the tax year for the December reconciliation,
+	// or of the month when income tax is withheld on the month's total
+	var priorPayslips []model.Payslip
+	for _, payslip := range records.yearPayslips[user.ID] {
+		if payslip.PayrollPeriodID != period.ID {
+			priorPayslips = append(priorPayslips, payslip)
+		}
+	}
+
+	// Get salary history
+	compensations := records.compensations[user.ID]
+
+	// Get earlier periods owed back pay
+	var retroPeriods []RetroPeriod
+	var err error
+	if !inputs.correction && len(compensations) > 0 {
+		retroPeriods, err = p.loadRetroPeriods(user, period, schedule, compensations, records.earlierPayslips[user.ID])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Get allowances assigned to the employee or their grade
+	assignments := records.allowances(user)
+
+	// Get leave balances to pay out on termination
+	var leaveBalances []model.LeaveBalance
+	var unsavedBalances []*model.LeaveBalance
+	if !inputs.correction {
+		leaveBalances, unsavedBalances, err = p.loadLeavePayouts(user, period)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Get deductions due
+	var deductions []model.Deduction
+	if !inputs.correction {
+		deductions = records.deductions[user.ID]
+	}
+
+	var netPayFloor model.Money
+	if user.Company != nil {
+		netPayFloor = user.Company.NetPayFloor
+	}
+
+	policy, divisor := resolveProration(user)
+
+	pc := &PayContext{
+		User:             user,
+		Period:           period,
+		WorkingDays:      workingDays,
+		CalendarDays:     calculateCalendarDays(period.StartDate, period.EndDate),
+		ProrationPolicy:  policy,
+		ProrationDivisor: divisor,
+		PayFrequency:     periodFrequency(period),
+		Holidays:         inputs.holidays,
+		Schedule:         schedule,
+		TaxTable:         inputs.taxTable,
+		SalarySegments:   employedSalarySegments(user, compensations, period.StartDate, period.EndDate),
+		Allowances:       resolveAllowances(assignments),
+		Attendances:      attendances,
+		Leaves:           leaves,
+		LeaveBalances:    leaveBalances,
+		Overtimes:        overtimes,
+		Reimbursements:   reimbursements,
+		PriorPayslips:    priorPayslips,
+		RetroPeriods:     retroPeriods,
+		Deductions:       deductions,
+		NetPayFloor:      netPayFloor,
+	}
+	if err := runPayComponents(p.components, pc); err != nil {
+		return nil, err
+	}
 
 	// Calculate overtime hours
 	var overtimeHours float64
@@ -172,18 +915,7 @@ func (p *PayrollUsecase) calculatePayslip(user *model.User, period *model.Payrol
 		overtimeHours += overtime.Hours
 	}
 
-	// Calculate reimbursement total
-	var reimbursementTotal float64
-	for _, reimbursement := range reimbursements {
-		reimbursementTotal += reimbursement.Amount
-	}
-
-	// Calculate salary components
-	dailySalary := user.Salary / 22 // Assuming 22 working days per month
-	basePay := dailySalary * float64(attendanceDays)
-	overtimePay := (user.Salary / 22 / 8) * 2 * overtimeHours // 2x hourly rate
-
-	totalPay := basePay + overtimePay + reimbursementTotal
+	punctuality := pc.Punctuality()
 
 	payslip := &model.Payslip{
 		BaseModel: model.BaseModel{
@@ -191,22 +923,280 @@ func (p *PayrollUsecase) calculatePayslip(user *model.User, period *model.Payrol
 		},
 		UserID:             user.ID,
 		PayrollPeriodID:    period.ID,
-		BaseSalary:         user.Salary,
+		Kind:               model.PayslipRegular,
+		Status:             model.PayslipActive,
+		User:               *user,
+		BaseSalary:         pc.Salary(),
 		WorkingDays:        workingDays,
-		AttendanceDays:     attendanceDays,
+		AttendanceDays:     len(attendances),
+		PaidLeaveDays:      pc.PaidLeaveDays(period.StartDate, period.EndDate),
+		ProrationPolicy:    policy,
+		LateMinutes:        punctuality.LateMinutes,
+		EarlyLeaveMinutes:  punctuality.EarlyLeaveMinutes,
 		OvertimeHours:      overtimeHours,
-		OvertimePay:        overtimePay,
-		ReimbursementTotal: reimbursementTotal,
-		TotalPay:           totalPay,
+		OvertimePay:        pc.SumByCode(model.PayCodeOvertime),
+		ReimbursementTotal: pc.SumByCode(model.PayCodeReimbursement),
+		TotalEarnings:      pc.Total(model.PayslipItemEarning),
+		TotalDeductions:    pc.Total(model.PayslipItemDeduction),
+		TaxableIncome:      pc.TaxableIncome(),
+		TaxDeductible:      pc.TaxDeductible(),
+		TaxAmount:          incomeTaxWithheld(pc.Items),
+		EmployerCost:       pc.Total(model.PayslipItemEmployerCost),
+		TotalPay:           pc.NetPay(),
+		Items:              pc.Items,
+		Records:            payslipRecords(attendances, overtimes, reimbursements),
+		SalarySegments:     payslipSalarySegments(pc.SalarySegments),
+
+		DeductionTransactions: pc.DeductionTransactions,
+		LeaveBalances:         unsavedBalances,
 	}
 
 	return payslip, nil
 }
 
-func (p *PayrollUsecase) calculateWorkingDays(startDate, endDate time.Time) int {
+// payslipRecords lists the records a payslip pays.
+func payslipRecords(attendances []model.Attendance, overtimes []model.Overtime, reimbursements []model.Reimbursement) []model.PayslipRecord {
+	records := make([]model.PayslipRecord, 0, len(attendances)+len(overtimes)+len(reimbursements))
+	for _, attendance := range attendances {
+		records = append(records, model.PayslipRecord{RecordType: model.PayslipRecordAttendance, RecordID: attendance.ID})
+	}
+	for _, overtime := range overtimes {
+		records = append(records, model.PayslipRecord{RecordType: model.PayslipRecordOvertime, RecordID: overtime.ID})
+	}
+	for _, reimbursement := range reimbursements {
+		records = append(records, model.PayslipRecord{RecordType: model.PayslipRecordReimbursement, RecordID: reimbursement.ID})
+	}
+	return records
+}
+
+// assignRecords stamps the records payslip paid with its period, so no
+// other period picks them up.
+func (p *PayrollUsecase) assignRecords(payslip *model.Payslip) error {
+	if err := p.attendanceRepo.AssignToPeriod(payslip.RecordIDs(model.PayslipRecordAttendance), payslip.PayrollPeriodID); err != nil {
+		return err
+	}
+	if err := p.overtimeRepo.AssignToPeriod(payslip.RecordIDs(model.PayslipRecordOvertime), payslip.PayrollPeriodID); err != nil {
+		return err
+	}
+	return p.reimbursementRepo.AssignToPeriod(payslip.RecordIDs(model.PayslipRecordReimbursement), payslip.PayrollPeriodID)
+}
+
+// assignPayslipRecords is assignRecords for all the payslips of a run at
+// once.
+func (p *PayrollUsecase) assignPayslipRecords(periodID uint, payslips []model.Payslip) error {
+	var attendanceIDs, overtimeIDs, reimbursementIDs []uint
+	for i := range payslips {
+		attendanceIDs = append(attendanceIDs, payslips[i].RecordIDs(model.PayslipRecordAttendance)...)
+		overtimeIDs = append(overtimeIDs, payslips[i].RecordIDs(model.PayslipRecordOvertime)...)
+		reimbursementIDs = append(reimbursementIDs, payslips[i].RecordIDs(model.PayslipRecordReimbursement)...)
+	}
+	if err := p.attendanceRepo.AssignToPeriod(attendanceIDs, periodID); err != nil {
+		return err
+	}
+	if err := p.overtimeRepo.AssignToPeriod(overtimeIDs, periodID); err != nil {
+		return err
+	}
+	return p.reimbursementRepo.AssignToPeriod(reimbursementIDs, periodID)
+}
+
+// markRecordsProcessed flags the records linked to a period as processed.
+func (p *PayrollUsecase) markRecordsProcessed(periodID uint) error {
+	if err := p.attendanceRepo.MarkAsProcessed(periodID); err != nil {
+		return err
+	}
+	if err := p.overtimeRepo.MarkAsProcessed(periodID); err != nil {
+		return err
+	}
+	return p.reimbursementRepo.MarkAsProcessed(periodID)
+}
+
+// settleDeductions applies the deduction transactions stored with payslip to
+// their deductions, moving balances and completing paid-off deductions.
+func (p *PayrollUsecase) settleDeductions(payslip *model.Payslip) error {
+	for i := range payslip.DeductionTransactions {
+		transaction := &payslip.DeductionTransactions[i]
+		deduction, err := p.deductionRepo.GetByID(transaction.DeductionID)
+		if err != nil {
+			return fmt.Errorf("deduction %d: %w", transaction.DeductionID, err)
+		}
+		deduction.Apply(transaction)
+		if err := p.deductionRepo.Update(deduction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revertDeductions undoes settleDeductions for a voided payslip, latest
+// transaction first.
+func (p *PayrollUsecase) revertDeductions(payslip *model.Payslip) error {
+	for i := len(payslip.DeductionTransactions) - 1; i >= 0; i-- {
+		transaction := &payslip.DeductionTransactions[i]
+		deduction, err := p.deductionRepo.GetByID(transaction.DeductionID)
+		if err != nil {
+			return fmt.Errorf("deduction %d: %w", transaction.DeductionID, err)
+		}
+		deduction.Revert(transaction)
+		if err := p.deductionRepo.Update(deduction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadLeavePayouts returns the balances to pay out when user's employment
+// ends within period, as of the termination date, without saving them.
+// unsaved lists what storing the payslip has to save: the balances, which
+// the payout lines point at, and the previous year balances they close.
+func (p *PayrollUsecase) loadLeavePayouts(user *model.User, period *model.PayrollPeriod) (balances []model.LeaveBalance, unsaved []*model.LeaveBalance, err error) {
+	if user.TerminationDate == nil || user.TerminationDate.Before(period.StartDate) || user.TerminationDate.After(period.EndDate) {
+		return nil, nil, nil
+	}
+
+	leaveTypes, err := p.leaveRepo.GetTypes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var closed []*model.LeaveBalance
+	for i := range leaveTypes {
+		if !leaveTypes[i].TracksBalance() || !leaveTypes[i].PayOutOnTermination {
+			continue
+		}
+		balance, previous, err := leaveBalanceAsOf(p.leaveRepo, user, &leaveTypes[i], user.TerminationDate.Year(), *user.TerminationDate)
+		if err != nil {
+			return nil, nil, err
+		}
+		balances = append(balances, *balance)
+		if previous != nil {
+			closed = append(closed, previous)
+		}
+	}
+
+	unsaved = closed
+	for i := range balances {
+		unsaved = append(unsaved, &balances[i])
+	}
+	return balances, unsaved, nil
+}
+
+// settleLeavePayouts records the days the payslip's leave payout lines paid
+// on their balances.
+func (p *PayrollUsecase) settleLeavePayouts(payslip *model.Payslip) error {
+	for _, item := range payslip.Items {
+		if item.LeaveBalanceID == nil {
+			continue
+		}
+		balance, err := p.leaveRepo.GetBalanceByID(*item.LeaveBalanceID)
+		if err != nil {
+			return fmt.Errorf("leave balance %d: %w", *item.LeaveBalanceID, err)
+		}
+		balance.PaidOut += item.Quantity
+		if err := p.leaveRepo.UpdateBalance(balance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revertLeavePayouts undoes settleLeavePayouts for a voided payslip.
+func (p *PayrollUsecase) revertLeavePayouts(payslip *model.Payslip) error {
+	for _, item := range payslip.Items {
+		if item.LeaveBalanceID == nil {
+			continue
+		}
+		balance, err := p.leaveRepo.GetBalanceByID(*item.LeaveBalanceID)
+		if err != nil {
+			return fmt.Errorf("leave balance %d: %w", *item.LeaveBalanceID, err)
+		}
+		balance.PaidOut -= item.Quantity
+		if err := p.leaveRepo.UpdateBalance(balance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadRetroPeriods finds the processed periods before period whose salary
+// segments under the current salary history differ from the ones their
+// payslip among payslips was paid with, and prices each with the inputs of
+// that payslip. payslips are the employee's payslips, latest first.
+func (p *PayrollUsecase) loadRetroPeriods(user *model.User, period *model.PayrollPeriod, schedule *EmployeeSchedule, compensations []model.Compensation, payslips []model.Payslip) ([]RetroPeriod, error) {
+	_, divisor := resolveProration(user)
+	var retroPeriods []RetroPeriod
+	seen := make(map[uint]bool)
+	for _, payslip := range payslips {
+		earlier := payslip.PayrollPeriod
+		if payslip.Kind != model.PayslipRegular || seen[earlier.ID] || !earlier.EndDate.Before(period.StartDate) {
+			continue
+		}
+		seen[earlier.ID] = true
+		if len(payslip.Items) == 0 {
+			continue // Payslips from before itemised pay have nothing to compare with
+		}
+
+		paidSegments, ok := paidSalarySegments(user, &payslip)
+		if !ok {
+			continue // The salaries it was paid with are not known
+		}
+		segments := employedSalarySegments(user, compensations, earlier.StartDate, earlier.EndDate)
+		paid := retroPaid(earlier.ID, payslips)
+		if sameSalarySegments(segments, paidSegments) && paid == 0 {
+			continue
+		}
+
+		// Price the period with the records the payslip paid; payslips from
+		// before records were linked use the period's attendance
+		records, err := p.payrollRepo.GetPayslipRecords(payslip.ID)
+		if err != nil {
+			return nil, err
+		}
+		payslip.Records = records
+		var attendances []model.Attendance
+		if len(payslip.Records) > 0 {
+			attendances, err = p.attendanceRepo.GetByIDs(payslip.RecordIDs(model.PayslipRecordAttendance))
+		} else {
+			attendances, err = p.attendanceRepo.GetByUserAndPeriod(user.ID, earlier.StartDate, earlier.EndDate)
+		}
+		if err != nil {
+			return nil, err
+		}
+		holidays, err := p.holidayRepo.GetByRange(earlier.StartDate, earlier.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		leaves, err := p.leaveRepo.GetApprovedByUserAndPeriod(user.ID, earlier.StartDate, earlier.EndDate)
+		if err != nil {
+			return nil, err
+		}
+
+		calendar := NewHolidayCalendar(holidays)
+		retroPeriods = append(retroPeriods, RetroPeriod{
+			Context: &PayContext{
+				User:             user,
+				Period:           &earlier,
+				WorkingDays:      payslip.WorkingDays,
+				CalendarDays:     calculateCalendarDays(earlier.StartDate, earlier.EndDate),
+				ProrationPolicy:  payslip.ProrationPolicy,
+				ProrationDivisor: divisor,
+				PayFrequency:     periodFrequency(&earlier),
+				Holidays:         calendar,
+				Schedule:         schedule,
+				SalarySegments:   segments,
+				Attendances:      attendances,
+				Leaves:           leaves,
+			},
+			PaidSegments: paidSegments,
+			Paid:         paid,
+		})
+	}
+	return retroPeriods, nil
+}
+
+func (p *PayrollUsecase) calculateWorkingDays(startDate, endDate time.Time, schedule *EmployeeSchedule, calendar HolidayCalendar) int {
 	workingDays := 0
 	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
-		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+		if schedule.IsWorkingDay(d, calendar) {
 			workingDays++
 		}
 	}
@@ -232,16 +1222,10 @@ func (p *PayrollUsecase) GeneratePayslip(userID uint, periodID *uint) (*dto.Pays
 		payslip = &payslips[0] // Latest payslip
 	}
 
-	// Get period details
-	period, err := p.payrollRepo.GetPeriodByID(payslip.PayrollPeriodID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Get detailed records
-	attendances, _ := p.attendanceRepo.GetByUserAndPeriod(userID, period.StartDate, period.EndDate)
-	overtimes, _ := p.overtimeRepo.GetByUserAndPeriod(userID, period.StartDate, period.EndDate)
-	reimbursements, _ := p.reimbursementRepo.GetByUserAndPeriod(userID, period.StartDate, period.EndDate)
+	// Get the records the payslip paid
+	attendances, _ := p.attendanceRepo.GetByIDs(payslip.RecordIDs(model.PayslipRecordAttendance))
+	overtimes, _ := p.overtimeRepo.GetByIDs(payslip.RecordIDs(model.PayslipRecordOvertime))
+	reimbursements, _ := p.reimbursementRepo.GetByIDs(payslip.RecordIDs(model.PayslipRecordReimbursement))
 
 	return &dto.PayslipResponse{
 		Payslip:        *payslip,
@@ -258,7 +1242,7 @@ func (p *PayrollUsecase) GetPayrollSummary(periodID uint) (*dto.PayrollSummaryRe
 		return nil, errors.New("payroll period not found")
 	}
 
-	if !period.IsProcessed {
+	if !period.Status.Processed() {
 		return nil, errors.New("payroll has not been processed yet")
 	}
 
@@ -268,8 +1252,15 @@ func (p *PayrollUsecase) GetPayrollSummary(periodID uint) (*dto.PayrollSummaryRe
 		return nil, err
 	}
 
-	// Calculate total payout
-	var totalPayout float64
+	return summarizePayslips(period, payslips), nil
+}
+
+// summarizePayslips builds the payroll summary of period from its payslips.
+func summarizePayslips(period *model.PayrollPeriod, payslips []model.Payslip) *dto.PayrollSummaryResponse {
+	// Calculate total payout and employer cost
+	var totalPayout, totalGross, totalContributions model.Money
+	costLines := make([]dto.PayrollCostLine, 0)
+	costLineIndex := make(map[string]int)
 	processedPayslips := make([]map[string]interface{}, len(payslips))
 	for i, payslip := range payslips {
 		userData := map[string]interface{}{
@@ -285,16 +1276,41 @@ func (p *PayrollUsecase) GetPayrollSummary(periodID uint) (*dto.PayrollSummaryRe
 			"base_salary":         payslip.BaseSalary,
 			"working_days":        payslip.WorkingDays,
 			"attendance_days":     payslip.AttendanceDays,
+			"paid_leave_days":     payslip.PaidLeaveDays,
+			"late_minutes":        payslip.LateMinutes,
+			"early_leave_minutes": payslip.EarlyLeaveMinutes,
 			"overtime_hours":      payslip.OvertimeHours,
 			"overtime_pay":        payslip.OvertimePay,
 			"reimbursement_total": payslip.ReimbursementTotal,
+			"total_earnings":      payslip.TotalEarnings,
+			"total_deductions":    payslip.TotalDeductions,
+			"taxable_income":      payslip.TaxableIncome,
+			"tax_amount":          payslip.TaxAmount,
+			"employer_cost":       payslip.EmployerCost,
 			"total_pay":           payslip.TotalPay,
 			"created_at":          payslip.CreatedAt,
 			"updated_at":          payslip.UpdatedAt,
+			"items":               payslip.Items,
 			"user":                userData,
 		}
 
 		totalPayout += payslip.TotalPay
+		totalGross += payslip.TotalEarnings
+		totalContributions += payslip.EmployerCost
+
+		for _, item := range payslip.Items {
+			if item.Type != model.PayslipItemEmployerCost {
+				continue
+			}
+			key := item.Code + "|" + item.Label
+			idx, ok := costLineIndex[key]
+			if !ok {
+				idx = len(costLines)
+				costLineIndex[key] = idx
+				costLines = append(costLines, dto.PayrollCostLine{Code: item.Code, Label: item.Label})
+			}
+			costLines[idx].Amount += item.Amount
+		}
 	}
 
 	return &dto.PayrollSummaryResponse{
@@ -302,5 +1318,10 @@ func (p *PayrollUsecase) GetPayrollSummary(periodID uint) (*dto.PayrollSummaryRe
 		Payslips:      processedPayslips,
 		TotalPayout:   totalPayout,
 		EmployeeCount: len(payslips),
-	}, nil
+
+		TotalGross:                 totalGross,
+		TotalEmployerContributions: totalContributions,
+		TotalEmployerCost:          totalGross + totalContributions,
+		EmployerCostLines:          costLines,
+	}
 }
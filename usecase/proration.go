@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"payroll/domain/model"
+	"time"
+)
+
+// ProrationBasis carries the day counts a proration policy works from.
+type ProrationBasis struct {
+	PaidDays     int // attended days and other days that are paid
+	WorkingDays  int // working days in the period
+	CalendarDays int // calendar days in the period
+	Divisor      int // fixed divisor configured for the company
+	// PeriodsPerYear is how often the employee is paid; zero means monthly.
+	PeriodsPerYear int
+}
+
+// periodSalary is the share of the monthly salary one pay period is worth.
+func (b ProrationBasis) periodSalary(salary model.Money) model.Money {
+	if b.PeriodsPerYear <= 0 || b.PeriodsPerYear == 12 {
+		return salary
+	}
+	return salary.MulDiv(12, int64(b.PeriodsPerYear))
+}
+
+func (b ProrationBasis) absences() int {
+	return max(b.WorkingDays-b.PaidDays, 0)
+}
+
+// ProrateBasePay returns the base pay and the daily rate shown next to it
+// for salary under the given policy.
+func ProrateBasePay(policy model.ProrationPolicy, salary model.Money, basis ProrationBasis) (amount, dailyRate model.Money) {
+	return ProrateSegment(policy, salary, basis, basis)
+}
+
+// ProrateSegment prorates salary for the part of a period it was in effect.
+// segment counts the days of that part and period the days of the whole
+// period; the day counts of the period are what the salary is divided by.
+// When segment and period are the same this is ProrateBasePay. salary is
+// monthly; periods paid more often than monthly prorate their share of it,
+// while the fixed divisor keeps dividing the monthly salary.
+func ProrateSegment(policy model.ProrationPolicy, salary model.Money, segment, period ProrationBasis) (amount, dailyRate model.Money) {
+	divisor := period.Divisor
+	if divisor <= 0 {
+		divisor = model.DefaultProrationDivisor
+	}
+	periodSalary := period.periodSalary(salary)
+
+	switch policy {
+	case model.ProrationWorkingDays:
+		if period.WorkingDays == 0 {
+			return 0, 0
+		}
+		return periodSalary.MulDiv(int64(segment.PaidDays), int64(period.WorkingDays)),
+			periodSalary.MulDiv(1, int64(period.WorkingDays))
+
+	case model.ProrationCalendarDays:
+		if period.CalendarDays == 0 {
+			return 0, 0
+		}
+		paidCalendarDays := max(segment.CalendarDays-segment.absences(), 0)
+		return periodSalary.MulDiv(int64(paidCalendarDays), int64(period.CalendarDays)),
+			periodSalary.MulDiv(1, int64(period.CalendarDays))
+
+	case model.ProrationSalaryMinusAbsences:
+		// The segment's share of the full salary, less its absences.
+		share := periodSalary
+		if segment.CalendarDays != period.CalendarDays && period.CalendarDays > 0 {
+			share = periodSalary.MulDiv(int64(segment.CalendarDays), int64(period.CalendarDays))
+		}
+		deduction := salary.MulDiv(int64(segment.absences()), int64(divisor))
+		return (share - deduction).Max(0), salary.MulDiv(1, int64(divisor))
+
+	default:
+		return salary.MulDiv(int64(segment.PaidDays), int64(divisor)),
+			salary.MulDiv(1, int64(divisor))
+	}
+}
+
+// resolveProration returns the policy and divisor that apply to user: the
+// employee override first, then the company setting, then the fixed 22-day
+// divisor used before policies were configurable.
+func resolveProration(user *model.User) (model.ProrationPolicy, int) {
+	policy := model.ProrationFixedDivisor
+	divisor := model.DefaultProrationDivisor
+
+	if user.Company != nil {
+		if user.Company.ProrationPolicy.IsValid() {
+			policy = user.Company.ProrationPolicy
+		}
+		if user.Company.ProrationDivisor > 0 {
+			divisor = user.Company.ProrationDivisor
+		}
+	}
+	if user.ProrationPolicy.IsValid() {
+		policy = user.ProrationPolicy
+	}
+
+	return policy, divisor
+}
+
+func calculateCalendarDays(startDate, endDate time.Time) int {
+	days := 0
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		days++
+	}
+	return days
+}
@@ -2,26 +2,60 @@ package usecase
 
 import (
 	"encoding/json"
+	"errors"
 	"payroll/domain/dto"
 	"payroll/domain/model"
 	"payroll/repositories"
+	"time"
 )
 
-func NewReimbursementUsecase(reimbursementRepo repositories.ReimbursementRepository, auditRepo repositories.AuditRepository) *ReimbursementUsecase {
+func NewReimbursementUsecase(reimbursementRepo repositories.ReimbursementRepository, userRepo repositories.UserRepository, payrollRepo repositories.PayrollRepository, auditRepo repositories.AuditRepository) *ReimbursementUsecase {
 	return &ReimbursementUsecase{
 		reimbursementRepo: reimbursementRepo,
+		userRepo:          userRepo,
+		payrollRepo:       payrollRepo,
 		auditRepo:         auditRepo,
 	}
 }
 
 func (r *ReimbursementUsecase) SubmitReimbursement(userID uint, req *dto.ReimbursementRequest, ipAddress, requestID string) error {
+	return r.createReimbursement(userID, req, false, userID, ipAddress, requestID)
+}
+
+// RecordReimbursement enters a reimbursement for an employee on an
+// administrator's behalf, also in processed periods so a correction run can
+// pay it.
+func (r *ReimbursementUsecase) RecordReimbursement(employeeID uint, req *dto.ReimbursementRequest, userID uint, ipAddress, requestID string) error {
+	return r.createReimbursement(employeeID, req, true, userID, ipAddress, requestID)
+}
+
+// createReimbursement stores the reimbursement of employeeID entered by
+// userID.
+func (r *ReimbursementUsecase) createReimbursement(employeeID uint, req *dto.ReimbursementRequest, correction bool, userID uint, ipAddress, requestID string) error {
+	// Parse date, today when left out
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if req.Date != "" {
+		var err error
+		date, err = time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			return errors.New("invalid date format")
+		}
+	}
+
+	// Check the date is still open for submissions
+	if err := checkSubmissionDate(r.userRepo, r.payrollRepo, employeeID, date, correction); err != nil {
+		return err
+	}
+
 	reimbursement := &model.Reimbursement{
 		BaseModel: model.BaseModel{
 			CreatedBy: &userID,
 			IPAddress: ipAddress,
 			RequestID: requestID,
 		},
-		UserID:      userID,
+		UserID:      employeeID,
+		Date:        date,
 		Amount:      req.Amount,
 		Description: req.Description,
 	}
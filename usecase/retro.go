@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"fmt"
+	"payroll/domain/model"
+)
+
+// RetroPeriod is an earlier, already processed period whose base pay is
+// recalculated because the salary in effect for it changed after it was
+// paid. Context prices the period with the inputs of its payslip and the
+// salary history as it is now; PaidSegments are the salaries the payslip
+// was paid with and Paid is the retro pay already paid for the period.
+type RetroPeriod struct {
+	Context      *PayContext
+	PaidSegments []SalarySegment
+	Paid         model.Money
+}
+
+// RetroPayComponent pays the back pay difference of every retro period on
+// its own line, linked to that period. A salary lowered retroactively gives
+// a recovery deduction. The base pay of the period is priced twice with the
+// same inputs, once with the salaries it was paid with and once with the
+// current ones, so only the salary change is paid. Overtime and
+// contributions of earlier periods stay as they were paid.
+type RetroPayComponent struct{}
+
+func (RetroPayComponent) Code() string {
+	return model.PayCodeRetro
+}
+
+func (RetroPayComponent) Calculate(pc *PayContext) ([]model.PayslipItem, error) {
+	var items []model.PayslipItem
+	for _, retro := range pc.RetroPeriods {
+		owed, err := basePayTotal(retro.Context)
+		if err != nil {
+			return nil, err
+		}
+		paidContext := *retro.Context
+		paidContext.SalarySegments = retro.PaidSegments
+		paid, err := basePayTotal(&paidContext)
+		if err != nil {
+			return nil, err
+		}
+		owed -= paid + retro.Paid
+		if owed == 0 {
+			continue
+		}
+
+		period := retro.Context.Period
+		item := model.PayslipItem{
+			Code:          model.PayCodeRetro,
+			Label:         fmt.Sprintf("Retro pay (%s to %s)", period.StartDate.Format("2006-01-02"), period.EndDate.Format("2006-01-02")),
+			Type:          model.PayslipItemEarning,
+			Quantity:      1,
+			Rate:          owed,
+			Amount:        owed,
+			Taxable:       true,
+			RetroPeriodID: &period.ID,
+		}
+		if owed < 0 {
+			item.Label = fmt.Sprintf("Retro pay recovery (%s to %s)", period.StartDate.Format("2006-01-02"), period.EndDate.Format("2006-01-02"))
+			item.Type = model.PayslipItemDeduction
+			item.Rate = -owed
+			item.Amount = -owed
+			item.Taxable = false
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// basePayTotal is the base pay of pc.
+func basePayTotal(pc *PayContext) (model.Money, error) {
+	items, err := BasePayComponent{}.Calculate(pc)
+	if err != nil {
+		return 0, err
+	}
+	var total model.Money
+	for _, item := range items {
+		total += item.Amount
+	}
+	return total, nil
+}
+
+// retroPaid sums the retro pay later payslips already paid for period.
+func retroPaid(periodID uint, payslips []model.Payslip) model.Money {
+	var paid model.Money
+	for _, payslip := range payslips {
+		for _, item := range payslip.Items {
+			if item.Code != model.PayCodeRetro || item.RetroPeriodID == nil || *item.RetroPeriodID != periodID {
+				continue
+			}
+			if item.Type == model.PayslipItemDeduction {
+				paid -= item.Amount
+			} else {
+				paid += item.Amount
+			}
+		}
+	}
+	return paid
+}
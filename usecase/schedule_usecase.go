@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"payroll/domain/dto"
+	"payroll/domain/model"
+	"payroll/repositories"
+	"time"
+)
+
+func NewScheduleUsecase(scheduleRepo repositories.ScheduleRepository, userRepo repositories.UserRepository, auditRepo repositories.AuditRepository) *ScheduleUsecase {
+	return &ScheduleUsecase{
+		scheduleRepo: scheduleRepo,
+		userRepo:     userRepo,
+		auditRepo:    auditRepo,
+	}
+}
+
+func (s *ScheduleUsecase) GetShifts() ([]model.Shift, error) {
+	return s.scheduleRepo.GetShifts()
+}
+
+func (s *ScheduleUsecase) CreateShift(req *dto.ShiftRequest, userID uint, ipAddress, requestID string) (*model.Shift, error) {
+	shift := &model.Shift{
+		BaseModel: model.BaseModel{
+			CreatedBy: &userID,
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+	}
+	if err := applyShift(shift, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.scheduleRepo.CreateShift(shift); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(shift)
+	s.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "CREATE",
+		TableName: "shifts",
+		RecordID:  &shift.ID,
+		NewData:   string(newData),
+	})
+
+	return shift, nil
+}
+
+// UpdateShift changes a shift for every schedule that uses it. Attendance
+// already recorded keeps the hours it was submitted with.
+func (s *ScheduleUsecase) UpdateShift(id uint, req *dto.ShiftRequest, userID uint, ipAddress, requestID string) (*model.Shift, error) {
+	shift, err := s.scheduleRepo.GetShiftByID(id)
+	if err != nil {
+		return nil, errors.New("shift not found")
+	}
+	oldData, _ := json.Marshal(shift)
+
+	if err := applyShift(shift, req); err != nil {
+		return nil, err
+	}
+	shift.UpdatedBy = &userID
+	shift.IPAddress = ipAddress
+	shift.RequestID = requestID
+
+	if err := s.scheduleRepo.UpdateShift(shift); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(shift)
+	s.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "UPDATE",
+		TableName: "shifts",
+		RecordID:  &shift.ID,
+		OldData:   string(oldData),
+		NewData:   string(newData),
+	})
+
+	return shift, nil
+}
+
+func applyShift(shift *model.Shift, req *dto.ShiftRequest) error {
+	shift.Code = req.Code
+	shift.Name = req.Name
+	shift.StartTime = req.StartTime
+	shift.EndTime = req.EndTime
+	shift.BreakMinutes = req.BreakMinutes
+	shift.LateGraceMinutes = req.LateGraceMinutes
+	shift.EarlyLeaveGraceMinutes = req.EarlyLeaveGraceMinutes
+
+	if _, _, err := shift.Times(time.Time{}); err != nil {
+		return err
+	}
+	if shift.ExpectedHours() <= 0 {
+		return errors.New("break is longer than the shift")
+	}
+	return nil
+}
+
+func (s *ScheduleUsecase) GetSchedules() ([]model.WorkSchedule, error) {
+	return s.scheduleRepo.GetSchedules()
+}
+
+func (s *ScheduleUsecase) CreateSchedule(req *dto.WorkScheduleRequest, userID uint, ipAddress, requestID string) (*model.WorkSchedule, error) {
+	anchorDate, err := time.Parse("2006-01-02", req.AnchorDate)
+	if err != nil {
+		return nil, errors.New("invalid anchor date format")
+	}
+
+	schedule := &model.WorkSchedule{
+		BaseModel: model.BaseModel{
+			CreatedBy: &userID,
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		Name:       req.Name,
+		CycleDays:  req.CycleDays,
+		AnchorDate: anchorDate,
+	}
+
+	seen := make(map[int]bool)
+	for _, day := range req.Days {
+		if day.DayIndex >= req.CycleDays {
+			return nil, fmt.Errorf("day %d is outside the %d day cycle", day.DayIndex, req.CycleDays)
+		}
+		if seen[day.DayIndex] {
+			return nil, fmt.Errorf("day %d has more than one shift", day.DayIndex)
+		}
+		seen[day.DayIndex] = true
+
+		shift, err := s.scheduleRepo.GetShiftByID(day.ShiftID)
+		if err != nil {
+			return nil, fmt.Errorf("shift %d not found", day.ShiftID)
+		}
+		schedule.Days = append(schedule.Days, model.WorkScheduleDay{
+			BaseModel: model.BaseModel{
+				CreatedBy: &userID,
+				IPAddress: ipAddress,
+				RequestID: requestID,
+			},
+			DayIndex: day.DayIndex,
+			ShiftID:  shift.ID,
+			Shift:    *shift,
+		})
+	}
+
+	if err := s.scheduleRepo.CreateSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(schedule)
+	s.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "CREATE",
+		TableName: "work_schedules",
+		RecordID:  &schedule.ID,
+		NewData:   string(newData),
+	})
+
+	return schedule, nil
+}
+
+func (s *ScheduleUsecase) GetAssignments(employeeID uint) ([]model.ScheduleAssignment, error) {
+	return s.scheduleRepo.GetAssignmentsByUser(employeeID)
+}
+
+// AssignSchedule puts an employee on a schedule from the effective date
+// until their next assignment.
+func (s *ScheduleUsecase) AssignSchedule(req *dto.ScheduleAssignmentRequest, userID uint, ipAddress, requestID string) (*model.ScheduleAssignment, error) {
+	employee, err := s.userRepo.GetByID(req.UserID)
+	if err != nil || employee.Role != "employee" {
+		return nil, errors.New("employee not found")
+	}
+
+	schedule, err := s.scheduleRepo.GetScheduleByID(req.WorkScheduleID)
+	if err != nil {
+		return nil, errors.New("work schedule not found")
+	}
+
+	effectiveDate, err := time.Parse("2006-01-02", req.EffectiveDate)
+	if err != nil {
+		return nil, errors.New("invalid effective date format")
+	}
+
+	existing, err := s.scheduleRepo.GetAssignmentsByUser(employee.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, assignment := range existing {
+		if dateKey(assignment.EffectiveDate) == dateKey(effectiveDate) {
+			return nil, errors.New("a schedule change already takes effect on this date")
+		}
+	}
+
+	assignment := &model.ScheduleAssignment{
+		BaseModel: model.BaseModel{
+			CreatedBy: &userID,
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:         employee.ID,
+		WorkScheduleID: schedule.ID,
+		EffectiveDate:  effectiveDate,
+		WorkSchedule:   *schedule,
+	}
+
+	if err := s.scheduleRepo.CreateAssignment(assignment); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(assignment)
+	s.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "CREATE",
+		TableName: "schedule_assignments",
+		RecordID:  &assignment.ID,
+		NewData:   string(newData),
+	})
+
+	return assignment, nil
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"payroll/domain/model"
+	"payroll/repositories"
+	"time"
+)
+
+// checkSubmissionDate refuses attendance, overtime and reimbursements dated
+// inside a payroll period of the employee's pay group that is still a draft,
+// locked or already processed, since the run would never pay them or the
+// period is not open yet, and dated further
+// ahead of today than the employee's company allows. Records an
+// administrator enters as a correction are only refused in closed periods;
+// a correction run pays those of processed periods.
+func checkSubmissionDate(userRepo repositories.UserRepository, payrollRepo repositories.PayrollRepository, userID uint, date time.Time, correction bool) error {
+	user, err := userRepo.GetByID(userID)
+	if err != nil || user.Role != model.RoleEmployee {
+		return errors.New("employee not found")
+	}
+
+	var futureDays int
+	if user.Company != nil {
+		futureDays = user.Company.SubmissionFutureDays
+	}
+	if latest := time.Now().AddDate(0, 0, futureDays); dateKeyAfter(date, latest) {
+		if futureDays == 0 {
+			return errors.New("date cannot be in the future")
+		}
+		return fmt.Errorf("date cannot be more than %d days in the future", futureDays)
+	}
+
+	periods, err := payrollRepo.GetOverlappingPeriods(user.PayGroupID, date, date)
+	if err != nil {
+		return err
+	}
+	for _, period := range periods {
+		if correction {
+			if period.Status == model.PeriodClosed {
+				return fmt.Errorf("date falls in payroll period %d, which is closed", period.ID)
+			}
+			continue
+		}
+		if period.Status == model.PeriodDraft {
+			return fmt.Errorf("date falls in payroll period %d, which is not open yet", period.ID)
+		}
+		if period.Status == model.PeriodLocked || period.Status.Processed() {
+			return fmt.Errorf("date falls in payroll period %d, which is %s; ask an administrator for a payroll correction", period.ID, period.Status)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"payroll/domain/dto"
+	"payroll/domain/model"
+	"payroll/repositories"
+	"sort"
+)
+
+func NewTaxUsecase(taxRepo repositories.TaxRepository, auditRepo repositories.AuditRepository) *TaxUsecase {
+	return &TaxUsecase{
+		taxRepo:   taxRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+func (t *TaxUsecase) GetTaxTable(year int) (*model.TaxTable, error) {
+	table, err := t.taxRepo.GetTable(year)
+	if err != nil {
+		return nil, errors.New("no income tax table for this year")
+	}
+	return table, nil
+}
+
+// ReplaceTaxTable validates and stores the rate tables of one year. Rows are
+// sorted the way the tax calculation reads them, so the request may list
+// them in any order.
+func (t *TaxUsecase) ReplaceTaxTable(req *dto.TaxTableRequest, userID uint, ipAddress, requestID string) (*model.TaxTable, error) {
+	table := &model.TaxTable{EffectiveYear: req.EffectiveYear}
+
+	categories := make(map[string]bool)
+	for _, row := range req.PTKP {
+		table.PTKP = append(table.PTKP, model.TaxPTKP{
+			Status:      row.Status,
+			Amount:      row.Amount,
+			TERCategory: row.TERCategory,
+		})
+		categories[row.TERCategory] = true
+	}
+
+	for _, row := range req.TERRates {
+		if !categories[row.Category] {
+			return nil, fmt.Errorf("TER category %q is not used by any PTKP status", row.Category)
+		}
+		table.TERRates = append(table.TERRates, model.TaxTERRate{
+			Category:        row.Category,
+			MaxIncome:       row.MaxIncome,
+			RateBasisPoints: row.RateBasisPoints,
+		})
+	}
+	for category := range categories {
+		if !hasOpenEndedRow(table.TERRates, category) {
+			return nil, fmt.Errorf("TER category %q has no row without an upper limit", category)
+		}
+	}
+
+	for _, row := range req.Brackets {
+		table.Brackets = append(table.Brackets, model.TaxBracket{
+			MaxIncome:       row.MaxIncome,
+			RateBasisPoints: row.RateBasisPoints,
+		})
+	}
+	sort.SliceStable(table.Brackets, func(i, j int) bool {
+		return openEndedLess(table.Brackets[i].MaxIncome, table.Brackets[j].MaxIncome)
+	})
+	if table.Brackets[len(table.Brackets)-1].MaxIncome != 0 {
+		return nil, errors.New("the last tax bracket must have no upper limit")
+	}
+
+	sort.SliceStable(table.TERRates, func(i, j int) bool {
+		a, b := table.TERRates[i], table.TERRates[j]
+		if a.Category != b.Category {
+			return a.Category < b.Category
+		}
+		return openEndedLess(a.MaxIncome, b.MaxIncome)
+	})
+
+	if err := t.taxRepo.ReplaceTable(table); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(table)
+	t.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "REPLACE",
+		TableName: "tax_tables",
+		NewData:   string(newData),
+	})
+
+	return table, nil
+}
+
+func hasOpenEndedRow(rates []model.TaxTERRate, category string) bool {
+	for _, rate := range rates {
+		if rate.Category == category && rate.MaxIncome == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// openEndedLess orders upper limits ascending with 0 (no limit) last.
+func openEndedLess(a, b model.Money) bool {
+	if a == 0 {
+		return false
+	}
+	if b == 0 {
+		return true
+	}
+	return a < b
+}
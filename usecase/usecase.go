@@ -11,24 +11,99 @@ type UserEmployeeUsecase struct {
 
 type AttendanceUsecase struct {
 	attendanceRepo repositories.AttendanceRepository
+	holidayRepo    repositories.HolidayRepository
+	scheduleRepo   repositories.ScheduleRepository
+	leaveRepo      repositories.LeaveRepository
+	userRepo       repositories.UserRepository
+	payrollRepo    repositories.PayrollRepository
 	auditRepo      repositories.AuditRepository
 }
 
 type OvertimeUsecase struct {
 	overtimeRepo repositories.OvertimeRepository
+	userRepo     repositories.UserRepository
+	payrollRepo  repositories.PayrollRepository
 	auditRepo    repositories.AuditRepository
 }
 
 type ReimbursementUsecase struct {
 	reimbursementRepo repositories.ReimbursementRepository
+	userRepo          repositories.UserRepository
+	payrollRepo       repositories.PayrollRepository
 	auditRepo         repositories.AuditRepository
 }
 
+type CompanyUsecase struct {
+	companyRepo repositories.CompanyRepository
+	auditRepo   repositories.AuditRepository
+}
+
+type HolidayUsecase struct {
+	holidayRepo repositories.HolidayRepository
+	auditRepo   repositories.AuditRepository
+	transactor  repositories.Transactor
+}
+
+type TaxUsecase struct {
+	taxRepo   repositories.TaxRepository
+	auditRepo repositories.AuditRepository
+}
+
+type CompensationUsecase struct {
+	compensationRepo repositories.CompensationRepository
+	userRepo         repositories.UserRepository
+	auditRepo        repositories.AuditRepository
+}
+
+type AllowanceUsecase struct {
+	allowanceRepo repositories.AllowanceRepository
+	userRepo      repositories.UserRepository
+	auditRepo     repositories.AuditRepository
+}
+
+type DeductionUsecase struct {
+	deductionRepo repositories.DeductionRepository
+	userRepo      repositories.UserRepository
+	auditRepo     repositories.AuditRepository
+}
+
 type PayrollUsecase struct {
 	payrollRepo       repositories.PayrollRepository
 	userRepo          repositories.UserRepository
 	attendanceRepo    repositories.AttendanceRepository
 	overtimeRepo      repositories.OvertimeRepository
 	reimbursementRepo repositories.ReimbursementRepository
+	holidayRepo       repositories.HolidayRepository
+	taxRepo           repositories.TaxRepository
+	deductionRepo     repositories.DeductionRepository
+	allowanceRepo     repositories.AllowanceRepository
+	compensationRepo  repositories.CompensationRepository
+	scheduleRepo      repositories.ScheduleRepository
+	leaveRepo         repositories.LeaveRepository
 	auditRepo         repositories.AuditRepository
+	transactor        repositories.Transactor
+	components        []PayComponent
+	workers           int
+}
+
+type ScheduleUsecase struct {
+	scheduleRepo repositories.ScheduleRepository
+	userRepo     repositories.UserRepository
+	auditRepo    repositories.AuditRepository
+}
+
+type LeaveUsecase struct {
+	leaveRepo      repositories.LeaveRepository
+	userRepo       repositories.UserRepository
+	attendanceRepo repositories.AttendanceRepository
+	holidayRepo    repositories.HolidayRepository
+	scheduleRepo   repositories.ScheduleRepository
+	auditRepo      repositories.AuditRepository
+	transactor     repositories.Transactor
+}
+
+type PayGroupUsecase struct {
+	payGroupRepo repositories.PayGroupRepository
+	payrollRepo  repositories.PayrollRepository
+	auditRepo    repositories.AuditRepository
 }
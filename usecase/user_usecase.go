@@ -1,11 +1,13 @@
 package usecase
 
 import (
+	"encoding/json"
 	"errors"
 	"payroll/domain/dto"
 	"payroll/domain/model"
 	"payroll/repositories"
 	"payroll/utils"
+	"time"
 )
 
 func NewUserUsecase(userRepo repositories.UserRepository, auditRepo repositories.AuditRepository) *UserEmployeeUsecase {
@@ -51,3 +53,117 @@ func (u *UserEmployeeUsecase) Login(req *dto.LoginRequest, ipAddress, requestID
 func (u *UserEmployeeUsecase) GetProfile(userID uint) (*model.User, error) {
 	return u.userRepo.GetByID(userID)
 }
+
+func (u *UserEmployeeUsecase) UpdateEmployee(employeeID uint, req *dto.EmployeeUpdateRequest, userID uint, ipAddress, requestID string) (*model.User, error) {
+	user, err := u.userRepo.GetByID(employeeID)
+	if err != nil || user.Role != "employee" {
+		return nil, errors.New("employee not found")
+	}
+	oldData, _ := json.Marshal(user)
+
+	if req.CompanyID != nil {
+		user.CompanyID = req.CompanyID
+		user.Company = nil // let the foreign key win on save
+	}
+	if req.PayGroupID != nil {
+		user.PayGroupID = req.PayGroupID
+		if *req.PayGroupID == 0 {
+			user.PayGroupID = nil
+		}
+		user.PayGroup = nil
+	}
+	if req.Grade != nil {
+		user.Grade = *req.Grade
+	}
+	if req.ProrationPolicy != nil {
+		policy := model.ProrationPolicy(*req.ProrationPolicy)
+		if policy != "" && !policy.IsValid() {
+			return nil, errors.New("invalid proration policy")
+		}
+		user.ProrationPolicy = policy
+	}
+	if req.TaxMarried != nil {
+		user.TaxMarried = *req.TaxMarried
+	}
+	if req.TaxDependents != nil {
+		if *req.TaxDependents < 0 {
+			return nil, errors.New("tax dependents cannot be negative")
+		}
+		user.TaxDependents = min(*req.TaxDependents, model.MaxTaxDependents)
+	}
+	if req.NPWP != nil {
+		user.NPWP = *req.NPWP
+	}
+	if err := applyEmployment(user, req); err != nil {
+		return nil, err
+	}
+	user.UpdatedBy = &userID
+	user.IPAddress = ipAddress
+	user.RequestID = requestID
+
+	if err := u.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	// Log audit
+	newData, _ := json.Marshal(user)
+	u.auditRepo.Create(&model.AuditLog{
+		BaseModel: model.BaseModel{
+			IPAddress: ipAddress,
+			RequestID: requestID,
+		},
+		UserID:    &userID,
+		Action:    "UPDATE",
+		TableName: "users",
+		RecordID:  &user.ID,
+		OldData:   string(oldData),
+		NewData:   string(newData),
+	})
+
+	return user, nil
+}
+
+// applyEmployment updates the employment status and dates of user. Setting a
+// termination date marks the employee terminated unless a status is given.
+func applyEmployment(user *model.User, req *dto.EmployeeUpdateRequest) error {
+	if req.HireDate != nil {
+		if *req.HireDate == "" {
+			user.HireDate = nil
+		} else {
+			hireDate, err := time.Parse("2006-01-02", *req.HireDate)
+			if err != nil {
+				return errors.New("invalid hire date format")
+			}
+			user.HireDate = &hireDate
+		}
+	}
+
+	if req.TerminationDate != nil {
+		if *req.TerminationDate == "" {
+			user.TerminationDate = nil
+		} else {
+			terminationDate, err := time.Parse("2006-01-02", *req.TerminationDate)
+			if err != nil {
+				return errors.New("invalid termination date format")
+			}
+			user.TerminationDate = &terminationDate
+			user.EmploymentStatus = model.EmploymentTerminated
+		}
+	}
+
+	if req.EmploymentStatus != nil {
+		status := model.EmploymentStatus(*req.EmploymentStatus)
+		if !status.IsValid() {
+			return errors.New("invalid employment status")
+		}
+		user.EmploymentStatus = status
+	}
+
+	if user.EmploymentStatus == model.EmploymentTerminated && user.TerminationDate == nil {
+		return errors.New("termination date is required for terminated employees")
+	}
+	if user.HireDate != nil && user.TerminationDate != nil && user.TerminationDate.Before(*user.HireDate) {
+		return errors.New("termination date cannot be before hire date")
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"payroll/domain/model"
+	"time"
+)
+
+// DefaultShift is worked Monday to Friday by employees without a schedule
+// assignment.
+var DefaultShift = model.Shift{Code: "DEFAULT", Name: "Office hours", StartTime: "09:00", EndTime: "17:00"}
+
+// EmployeeSchedule resolves the shift an employee works on a given day from
+// their schedule assignments. A nil *EmployeeSchedule is the default
+// Monday to Friday schedule.
+type EmployeeSchedule struct {
+	assignments []model.ScheduleAssignment
+}
+
+// NewEmployeeSchedule expects assignments ordered by effective date.
+func NewEmployeeSchedule(assignments []model.ScheduleAssignment) *EmployeeSchedule {
+	return &EmployeeSchedule{assignments: assignments}
+}
+
+// ShiftOn returns the shift worked on date, or nil on a rest day.
+func (s *EmployeeSchedule) ShiftOn(date time.Time) *model.Shift {
+	var current *model.ScheduleAssignment
+	if s != nil {
+		for i := range s.assignments {
+			if dateKey(s.assignments[i].EffectiveDate) > dateKey(date) {
+				break
+			}
+			current = &s.assignments[i]
+		}
+	}
+
+	if current == nil {
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			return nil
+		}
+		return &DefaultShift
+	}
+	return current.WorkSchedule.ShiftOn(date)
+}
+
+// IsWorkingDay reports whether date has a shift and is neither a public
+// holiday nor a collective leave day.
+func (s *EmployeeSchedule) IsWorkingDay(date time.Time, holidays HolidayCalendar) bool {
+	if s.ShiftOn(date) == nil {
+		return false
+	}
+	_, isHoliday := holidays.Lookup(date)
+	return !isHoliday
+}
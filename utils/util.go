@@ -48,6 +48,22 @@ func ErrorResponse(c *gin.Context, statusCode int, message string, err error) {
 	c.JSON(statusCode, response)
 }
 
+// ErrorResponseWithData is ErrorResponse with details the client can act on,
+// such as which records failed.
+func ErrorResponseWithData(c *gin.Context, statusCode int, message string, err error, data interface{}) {
+	response := ErrorRes{
+		Status:  "error",
+		Message: message,
+		Data:    data,
+	}
+
+	if err != nil {
+		response.Error = err.Error()
+	}
+
+	c.JSON(statusCode, response)
+}
+
 func SuccessResponse(c *gin.Context, statusCode int, message string, data interface{}) {
 	response := SuccessRes{
 		Status:  "success",